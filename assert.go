@@ -30,12 +30,17 @@ import (
 
 // Error fails the test if err is nil.
 func Error(t TestingT, err error, args ...any) bool {
+	countAssertion(t)
+	if err != nil {
+		return true
+	}
 	msgArgs := []any{"Expected error"}
-	return !FailIf(
+	fail(
 		t,
-		err == nil,
+		true,
 		append(msgArgs, args...)...,
 	)
+	return false
 }
 
 // ErrorContains fails the test if the err is nil or if it does not contain the substring.
@@ -47,299 +52,366 @@ func ErrorContains(t TestingT, err error, substr string, args ...any) bool {
 
 // NoError fails the test if err is not nil.
 func NoError(t TestingT, err error, args ...any) bool {
+	countAssertion(t)
+	return noError(t, err, args...)
+}
+
+// noError fails the test if the error is not nil, without counting an assertion.
+func noError(t TestingT, err error, args ...any) bool {
+	if err == nil {
+		return true
+	}
 	msgArgs := []any{"Expected no error", err}
-	return !FailIf(
+	fail(
 		t,
-		err != nil,
+		true,
 		append(msgArgs, args...)...,
 	)
+	return false
 }
 
 // Equal fails the test if the two values are not equal.
 // Note: the expected value comes before the actual value in the argument list.
 func Equal(t TestingT, expected any, actual any, args ...any) bool {
+	countAssertion(t)
+	return equal(t, expected, actual, args...)
+}
+
+// equal fails the test if the two values are not equal, without counting an assertion.
+func equal(t TestingT, expected any, actual any, args ...any) bool {
 	nilActual := isNil(actual)
 	nilExpected := isNil(expected)
-	if nilActual && nilExpected {
+	if nilActual && nilExpected || reflect.DeepEqual(expected, actual) {
 		return true
 	}
-	var msgArgs []any
-	if !nilActual && !nilExpected && reflect.TypeOf(actual) != reflect.TypeOf(expected) {
-		msgArgs = []any{"Expected type %v, actual type %v", reflect.TypeOf(expected), reflect.TypeOf(actual)}
-	} else {
-		msgArgs = []any{"Expected '%v', actual '%v'", v(expected), v(actual)}
-	}
-	return !FailIf(
+	msgArgs := expectedActualArgs(expected, actual)
+	fail(
 		t,
-		!reflect.DeepEqual(expected, actual),
+		true,
 		append(msgArgs, args...)...,
 	)
+	return false
 }
 
 // NotEqual fails the test if the two values are equal.
 // Note: the expected value comes before the actual value in the argument list.
 func NotEqual(t TestingT, unexpected any, actual any, args ...any) bool {
+	countAssertion(t)
 	nilActual := isNil(actual)
 	nilUnexpected := isNil(unexpected)
-	if nilActual != nilUnexpected {
+	if nilActual != nilUnexpected || !nilActual && !reflect.DeepEqual(unexpected, actual) {
 		return true
 	}
 	msgArgs := []any{"Unexpected to equal '%v'", v(unexpected)}
-	return !FailIf(
+	fail(
 		t,
-		(nilActual && nilUnexpected) || reflect.DeepEqual(unexpected, actual),
+		true,
 		append(msgArgs, args...)...,
 	)
+	return false
 }
 
 // Zero fails the test if the value is not the 0 value of its type.
 // Nils are considered zero.
 func Zero(t TestingT, actual any, args ...any) bool {
+	countAssertion(t)
+	if isNil(actual) || reflect.ValueOf(actual).IsZero() {
+		return true
+	}
 	msgArgs := []any{"Expected zero, actual '%v'", v(actual)}
-	return !FailIf(
+	fail(
 		t,
-		!isNil(actual) && !reflect.ValueOf(actual).IsZero(),
+		true,
 		append(msgArgs, args...)...,
 	)
+	return false
 }
 
 // NotZero fails the test if the value is the 0 value of its type.
 // Nils are considered zero.
 func NotZero(t TestingT, actual any, args ...any) bool {
+	countAssertion(t)
+	if !isNil(actual) && !reflect.ValueOf(actual).IsZero() {
+		return true
+	}
 	msgArgs := []any{"Expected not to be zero, actual '%v'", v(actual)}
-	return !FailIf(
+	fail(
 		t,
-		isNil(actual) || reflect.ValueOf(actual).IsZero(),
+		true,
 		append(msgArgs, args...)...,
 	)
+	return false
 }
 
 // True fails the test if the condition is false.
 func True(t TestingT, condition bool, args ...any) bool {
+	countAssertion(t)
+	if condition {
+		return true
+	}
 	msgArgs := []any{"Expected condition to be true"}
-	return !FailIf(
+	fail(
 		t,
-		!condition,
+		true,
 		append(msgArgs, args...)...,
 	)
+	return false
 }
 
 // False fails the test if the condition is true.
 func False(t TestingT, condition bool, args ...any) bool {
+	countAssertion(t)
+	if !condition {
+		return true
+	}
 	msgArgs := []any{"Expected condition to be false"}
-	return !FailIf(
+	fail(
 		t,
-		condition,
+		true,
 		append(msgArgs, args...)...,
 	)
+	return false
 }
 
 // Match fails the test if a string doesn't match a regular expression.
 func Match(t TestingT, whole string, regexpStr string, args ...any) bool {
+	countAssertion(t)
 	re, err := regexp.Compile(regexpStr)
 	if err != nil {
 		msgArgs := []any{"Invalid regular expression '%s': %s", regexpStr, err.Error()}
-		FailIf(
+		fail(
 			t,
 			true,
 			append(msgArgs, args...)...,
 		)
 		return false
 	}
+	if re.MatchString(whole) {
+		return true
+	}
 	msgArgs := []any{"Expected '%v' to match regular expression '%v'", v(whole), v(regexpStr)}
-	return !FailIf(
+	fail(
 		t,
-		!re.MatchString(whole),
+		true,
 		append(msgArgs, args...)...,
 	)
+	return false
 }
 
 // NotMatch fails the test if a string matches a regular expression.
 func NotMatch(t TestingT, whole string, regexpStr string, args ...any) bool {
+	countAssertion(t)
 	re, err := regexp.Compile(regexpStr)
 	if err != nil {
 		msgArgs := []any{"Invalid regular expression '%s': %s", regexpStr, err.Error()}
-		FailIf(
+		fail(
 			t,
 			true,
 			append(msgArgs, args...)...,
 		)
 		return false
 	}
+	if !re.MatchString(whole) {
+		return true
+	}
 	msgArgs := []any{"Expected '%v' not to match regular expression '%v'", v(whole), v(regexpStr)}
-	return !FailIf(
+	fail(
 		t,
-		re.MatchString(whole),
+		true,
 		append(msgArgs, args...)...,
 	)
+	return false
+}
+
+// hasPrefix fails the test if a string doesn't start with the prefix.
+func hasPrefix(t TestingT, whole string, prefix string, args ...any) bool {
+	countAssertion(t)
+	if strings.HasPrefix(whole, prefix) {
+		return true
+	}
+	msgArgs := []any{"Expected '%v' to start with '%v'", v(whole), v(prefix)}
+	fail(
+		t,
+		true,
+		append(msgArgs, args...)...,
+	)
+	return false
+}
+
+// hasSuffix fails the test if a string doesn't end with the suffix.
+func hasSuffix(t TestingT, whole string, suffix string, args ...any) bool {
+	countAssertion(t)
+	if strings.HasSuffix(whole, suffix) {
+		return true
+	}
+	msgArgs := []any{"Expected '%v' to end with '%v'", v(whole), v(suffix)}
+	fail(
+		t,
+		true,
+		append(msgArgs, args...)...,
+	)
+	return false
 }
 
 // Contains fails the test if a string or error don't contain a substring,
 // or if a byte slice doesn't contain a byte subslice,
-// or if a slice doesn't contain an element,
-// or if a map doesn't contain a key.
+// or if a slice or an iter.Seq doesn't contain an element,
+// or if a map or an iter.Seq2 doesn't contain a key.
 func Contains(t TestingT, whole any, sub any, args ...any) bool {
-	msgArgs := []any{"Nil is not a container"}
-	if FailIf(
-		t,
-		isNil(whole),
-		append(msgArgs, args...)...,
-	) {
+	countAssertion(t)
+	if isNil(whole) {
+		msgArgs := []any{"Nil is not a container"}
+		fail(
+			t,
+			true,
+			append(msgArgs, args...)...,
+		)
 		return false
 	}
 	if err, ok := whole.(error); ok {
 		whole = err.Error()
 	}
-	msgArgs = []any{"Expected '%v' to contain '%v'", v(whole), v(sub)}
-	// Strings
-	if w, ok := whole.(string); ok {
-		if s, ok := sub.(string); ok {
-			return !FailIf(
-				t,
-				!strings.Contains(w, s),
-				append(msgArgs, args...)...,
-			)
-		}
-		if b, ok := sub.([]byte); ok {
-			return !FailIf(
-				t,
-				!strings.Contains(w, string(b)),
-				append(msgArgs, args...)...,
-			)
-		}
-	}
-	// []byte
-	if w, ok := whole.([]byte); ok {
-		if b, ok := sub.([]byte); ok {
-			return !FailIf(
-				t,
-				!bytes.Contains(w, b),
-				append(msgArgs, args...)...,
-			)
-		}
-		if s, ok := sub.(string); ok {
-			msgArgs = []any{"Expected '%v' to contain '%v'", v(string(w)), v(s)}
-			return !FailIf(
-				t,
-				!strings.Contains(string(w), s),
-				append(msgArgs, args...)...,
-			)
-		}
-	}
-	wholeValue := reflect.ValueOf(whole)
-	if wholeValue.Type().Kind() == reflect.Slice || wholeValue.Type().Kind() == reflect.Array {
-		found := false
-		for i := range wholeValue.Len() {
-			if reflect.DeepEqual(wholeValue.Index(i).Interface(), sub) {
-				found = true
-				break
-			}
-		}
-		return !FailIf(
-			t,
-			!found,
-			append(msgArgs, args...)...,
-		)
-	}
-	if wholeValue.Type().Kind() == reflect.Map {
-		found := false
-		for _, key := range wholeValue.MapKeys() {
-			if reflect.DeepEqual(key.Interface(), sub) {
-				found = true
-				break
-			}
-		}
-		return !FailIf(
-			t,
-			!found,
-			append(msgArgs, args...)...,
-		)
+	found, supported, items, exceeded := containsSub(whole, sub)
+	if found {
+		return true
 	}
-	msgArgs = []any{"Type %v doesn't support containment", wholeValue.Type()}
-	return !FailIf(
+	var msgArgs []any
+	switch {
+	case !supported:
+		msgArgs = []any{"Type %v doesn't support containment", reflect.TypeOf(whole)}
+	case exceeded:
+		msgArgs = []any{"Iterator exceeded %d items", seqLimit}
+	case seqArity(reflect.TypeOf(whole)) > 0:
+		msgArgs = []any{"Expected '%v' to contain '%v'", v(items), v(sub)}
+	default:
+		msgArgs = []any{"Expected '%v' to contain '%v'", v(containerValue(whole, sub)), v(sub)}
+	}
+	fail(
 		t,
 		true,
 		append(msgArgs, args...)...,
 	)
+	return false
 }
 
 // NotContains fails the test if a string or error contain a substring,
 // or if a byte slice contains a byte subslice,
-// or if a slice contains an element,
-// or if a map contains a key.
+// or if a slice or an iter.Seq contains an element,
+// or if a map or an iter.Seq2 contains a key.
 func NotContains(t TestingT, whole any, sub any, args ...any) bool {
+	countAssertion(t)
 	if isNil(whole) {
 		return true
 	}
 	if err, ok := whole.(error); ok {
 		whole = err.Error()
 	}
-	msgArgs := []any{"Expected '%v' not to contain '%v'", v(whole), v(sub)}
+	found, supported, items, exceeded := containsSub(whole, sub)
+	if !supported || !found && !exceeded {
+		return true
+	}
+	var msgArgs []any
+	switch {
+	case exceeded:
+		msgArgs = []any{"Iterator exceeded %d items", seqLimit}
+	case seqArity(reflect.TypeOf(whole)) > 0:
+		msgArgs = []any{"Expected iterator not to contain '%v', found at position %d", v(sub), len(items) - 1}
+	default:
+		msgArgs = []any{"Expected '%v' not to contain '%v'", v(containerValue(whole, sub)), v(sub)}
+	}
+	fail(
+		t,
+		true,
+		append(msgArgs, args...)...,
+	)
+	return false
+}
+
+// containsSub indicates if the whole contains the sub, and if the type of the whole supports containment at all.
+// For iterators, it also returns the items iterated over until the sub was found,
+// and whether the iterator exceeded the maximum number of items.
+func containsSub(whole any, sub any) (found bool, supported bool, items []any, exceeded bool) {
 	// Strings
 	if w, ok := whole.(string); ok {
 		if s, ok := sub.(string); ok {
-			return !FailIf(
-				t,
-				strings.Contains(w, s),
-				append(msgArgs, args...)...,
-			)
+			return strings.Contains(w, s), true, nil, false
 		}
 		if b, ok := sub.([]byte); ok {
-			return !FailIf(
-				t,
-				strings.Contains(w, string(b)),
-				append(msgArgs, args...)...,
-			)
+			return strings.Contains(w, string(b)), true, nil, false
 		}
 	}
 	// []byte
 	if w, ok := whole.([]byte); ok {
 		if b, ok := sub.([]byte); ok {
-			return !FailIf(
-				t,
-				bytes.Contains(w, b),
-				append(msgArgs, args...)...,
-			)
+			return bytes.Contains(w, b), true, nil, false
 		}
 		if s, ok := sub.(string); ok {
-			msgArgs = []any{"Expected '%v' not to contain '%v'", v(string(w)), v(s)}
-			return !FailIf(
-				t,
-				strings.Contains(string(w), s),
-				append(msgArgs, args...)...,
-			)
+			return strings.Contains(string(w), s), true, nil, false
 		}
 	}
 	wholeValue := reflect.ValueOf(whole)
-	if wholeValue.Type().Kind() == reflect.Slice || wholeValue.Type().Kind() == reflect.Array {
-		found := false
+	if seqArity(wholeValue.Type()) > 0 {
+		items, found, exceeded = seqItems(wholeValue, seqLimit, func(key any) bool {
+			return reflect.DeepEqual(key, sub)
+		})
+		return found, true, items, exceeded
+	}
+	if wholeValue.Kind() == reflect.Slice || wholeValue.Kind() == reflect.Array {
 		for i := range wholeValue.Len() {
-			if reflect.DeepEqual(wholeValue.Index(i).Interface(), sub) {
-				found = true
-				break
+			if equalElement(wholeValue.Index(i), sub) {
+				return true, true, nil, false
 			}
 		}
-		return !FailIf(
-			t,
-			found,
-			append(msgArgs, args...)...,
-		)
+		return false, true, nil, false
 	}
-	if wholeValue.Type().Kind() == reflect.Map {
-		found := false
-		for _, key := range wholeValue.MapKeys() {
-			if reflect.DeepEqual(key.Interface(), sub) {
-				found = true
-				break
+	if wholeValue.Kind() == reflect.Map {
+		subValue := reflect.ValueOf(sub)
+		if subValue.IsValid() && subValue.Type() == wholeValue.Type().Key() && isBasicKind(subValue.Kind()) {
+			return wholeValue.MapIndex(subValue).IsValid(), true, nil, false
+		}
+		mapIter := wholeValue.MapRange()
+		for mapIter.Next() {
+			if equalElement(mapIter.Key(), sub) {
+				return true, true, nil, false
 			}
 		}
-		return !FailIf(
-			t,
-			found,
-			append(msgArgs, args...)...,
-		)
+		return false, true, nil, false
 	}
-	return true
+	return false, false, nil, false
+}
+
+// containerValue returns the whole as it is shown in failure messages of containment.
+// A byte slice searched for a string is shown as a string.
+func containerValue(whole any, sub any) any {
+	if w, ok := whole.([]byte); ok {
+		if _, ok := sub.(string); ok {
+			return string(w)
+		}
+	}
+	return whole
+}
+
+// equalElement indicates if an element of a slice, array or map is deeply equal to the value.
+// Elements of basic kinds are compared without boxing them.
+func equalElement(elem reflect.Value, value any) bool {
+	valueValue := reflect.ValueOf(value)
+	if valueValue.IsValid() && elem.Type() == valueValue.Type() && isBasicKind(elem.Kind()) {
+		return elem.Equal(valueValue)
+	}
+	return reflect.DeepEqual(elem.Interface(), value)
+}
+
+// isBasicKind indicates if values of the kind are deeply equal if and only if they are ==.
+func isBasicKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128,
+		reflect.String:
+		return true
+	}
+	return false
 }
 
 // SliceContains fails the test if the slice does not contain the item.
@@ -377,10 +449,18 @@ func MapLen[K comparable, V any](t TestingT, m map[K]V, length int, args ...any)
 	return Len(t, m, length, args...)
 }
 
-// Len fails the test if the length of the string, slice, array, map or chan does not match the expected len.
+// Len fails the test if the length of the string, slice, array, map, chan, iter.Seq or iter.Seq2 does not match the expected len.
 func Len(t TestingT, obj any, length int, args ...any) bool {
+	countAssertion(t)
 	actualLength := 0
-	if !isNil(obj) {
+	if !isNil(obj) && seqArity(reflect.TypeOf(obj)) > 0 {
+		items, _, exceeded := seqItems(reflect.ValueOf(obj), seqLimit, nil)
+		if exceeded {
+			fail(t, true, "Iterator exceeded %d items", seqLimit)
+			return false
+		}
+		actualLength = len(items)
+	} else if !isNil(obj) {
 		objType := reflect.TypeOf(obj)
 		hasLength := false ||
 			objType.Kind() == reflect.Slice ||
@@ -388,17 +468,22 @@ func Len(t TestingT, obj any, length int, args ...any) bool {
 			objType.Kind() == reflect.Map ||
 			objType.Kind() == reflect.String ||
 			objType.Kind() == reflect.Chan
-		if FailIf(t, !hasLength, "Type %v doesn't have a length", objType) {
+		if !hasLength {
+			fail(t, true, "Type %v doesn't have a length", objType)
 			return false
 		}
 		actualLength = reflect.ValueOf(obj).Len()
 	}
+	if actualLength == length {
+		return true
+	}
 	msgArgs := []any{"Expected length %d, actual %d", length, actualLength}
-	return !FailIf(
+	fail(
 		t,
-		actualLength != length,
+		true,
 		append(msgArgs, args...)...,
 	)
+	return false
 }
 
 // SliceEqual fails the test if the two values are not equal.
@@ -415,32 +500,47 @@ func SliceNotEqual[T comparable](t TestingT, expected []T, actual []T, args ...a
 	return NotEqual(t, expected, actual, args...)
 }
 
-// isNil checks for nil of an interface.
+// isNil checks for nil of an interface, or of a nillable value held by an interface.
 func isNil(obj any) bool {
-	defer func() { recover() }()
-	return obj == nil || reflect.ValueOf(obj).IsNil()
+	if obj == nil {
+		return true
+	}
+	objValue := reflect.ValueOf(obj)
+	switch objValue.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Pointer, reflect.Slice, reflect.UnsafePointer:
+		return objValue.IsNil()
+	}
+	return false
 }
 
 // Nil fails the test if the object is not nil.
 func Nil(t TestingT, obj any, args ...any) bool {
-	isNil := isNil(obj)
+	countAssertion(t)
+	if isNil(obj) {
+		return true
+	}
 	msgArgs := []any{"Expected nil, actual '%v'", v(obj)}
-	return !FailIf(
+	fail(
 		t,
-		!isNil,
+		true,
 		append(msgArgs, args...)...,
 	)
+	return false
 }
 
 // NotNil fails the test if the object is nil.
 func NotNil(t TestingT, obj any, args ...any) bool {
-	isNil := isNil(obj)
+	countAssertion(t)
+	if !isNil(obj) {
+		return true
+	}
 	msgArgs := []any{"Expected not to be nil"}
-	return !FailIf(
+	fail(
 		t,
-		isNil,
+		true,
 		append(msgArgs, args...)...,
 	)
+	return false
 }
 
 /*
@@ -451,17 +551,19 @@ Note: the expected value comes after the actual value in each pair.
 	Expect(t, result, 4321, err, nil)
 */
 func Expect(t TestingT, actualExpectedPairs ...any) bool {
-	if FailIf(
-		t,
-		len(actualExpectedPairs)%2 != 0,
-		"Expected an even number of arguments",
-	) {
+	countAssertion(t)
+	if len(actualExpectedPairs)%2 != 0 {
+		fail(
+			t,
+			true,
+			"Expected an even number of arguments",
+		)
 		return false
 	}
 	// Test err==nil first and fail fast - for checking return args from function calls
 	for i := 0; i < len(actualExpectedPairs); i += 2 {
 		if err, ok := actualExpectedPairs[i].(error); ok && isNil(actualExpectedPairs[i+1]) {
-			if !NoError(t, err) {
+			if !noError(t, err) {
 				return false
 			}
 		}
@@ -469,7 +571,7 @@ func Expect(t TestingT, actualExpectedPairs ...any) bool {
 	// Test all pairs
 	result := true
 	for i := 0; i < len(actualExpectedPairs); i += 2 {
-		result = result && Equal(t, actualExpectedPairs[i+1], actualExpectedPairs[i])
+		result = result && equal(t, actualExpectedPairs[i+1], actualExpectedPairs[i])
 	}
 	return result
 }
@@ -485,6 +587,7 @@ Examples:
 	HTMLMatch(t, html, `TR TD INPUT[name="x"]`, `[0-9]+``)
 */
 func HTMLMatch(t TestingT, htmlBody []byte, cssSelectorQuery string, innerTextRegExp string, args ...any) bool {
+	countAssertion(t)
 	doc, selector, re, ok := parseDocSelectorAndRegexp(t, htmlBody, cssSelectorQuery, innerTextRegExp)
 	if !ok {
 		return false
@@ -509,17 +612,21 @@ func HTMLMatch(t TestingT, htmlBody []byte, cssSelectorQuery string, innerTextRe
 			break
 		}
 	}
+	if found {
+		return true
+	}
 	var msgArgs []any
 	if len(matches) == 0 {
 		msgArgs = []any{"No HTML element matched '%s'", cssSelectorQuery}
 	} else {
 		msgArgs = []any{"No HTML element matching '%s' and '%s'", cssSelectorQuery, innerTextRegExp}
 	}
-	return !FailIf(
+	fail(
 		t,
-		!found,
+		true,
 		append(msgArgs, args...)...,
 	)
+	return false
 }
 
 /*
@@ -533,6 +640,7 @@ Examples:
 	HTMLNotMatch(t, html, `TR TD INPUT[name="x"]`, `[0-9]+``)
 */
 func HTMLNotMatch(t TestingT, htmlBody []byte, cssSelectorQuery string, innerTextRegExp string, args ...any) bool {
+	countAssertion(t)
 	doc, selector, re, ok := parseDocSelectorAndRegexp(t, htmlBody, cssSelectorQuery, innerTextRegExp)
 	if !ok {
 		return false
@@ -557,19 +665,23 @@ func HTMLNotMatch(t TestingT, htmlBody []byte, cssSelectorQuery string, innerTex
 			break
 		}
 	}
+	if !found {
+		return true
+	}
 	msgArgs := []any{"An HTML element matched '%s' and '%s'", cssSelectorQuery, innerTextRegExp}
-	return !FailIf(
+	fail(
 		t,
-		found,
+		true,
 		append(msgArgs, args...)...,
 	)
+	return false
 }
 
 func parseDocSelectorAndRegexp(t TestingT, htmlBody []byte, cssSelectorQuery string, regexpSearchStr string) (doc *html.Node, selector cascadia.Selector, re *regexp.Regexp, ok bool) {
 	var err error
 	doc, err = html.Parse(bytes.NewReader(htmlBody))
 	if err != nil {
-		FailIf(
+		fail(
 			t,
 			true,
 			"Failed to parse HTML: %s", err.Error(),
@@ -578,7 +690,7 @@ func parseDocSelectorAndRegexp(t TestingT, htmlBody []byte, cssSelectorQuery str
 	}
 	selector, err = cascadia.Compile(cssSelectorQuery)
 	if err != nil {
-		FailIf(
+		fail(
 			t,
 			true,
 			"Invalid CSS selector query '%s': %s", cssSelectorQuery, err.Error(),
@@ -587,7 +699,7 @@ func parseDocSelectorAndRegexp(t TestingT, htmlBody []byte, cssSelectorQuery str
 	}
 	re, err = regexp.Compile(regexpSearchStr)
 	if err != nil {
-		FailIf(
+		fail(
 			t,
 			true,
 			"Invalid regular expression '%s': %s", regexpSearchStr, err.Error(),
@@ -597,28 +709,48 @@ func parseDocSelectorAndRegexp(t TestingT, htmlBody []byte, cssSelectorQuery str
 	return doc, selector, re, true
 }
 
-// v converts o to a string of no more than 1K in length.
-func v(o any) string {
-	truncate := func(s string) string {
-		if len(s) <= 1024 {
-			return s
-		}
-		rs := []rune(s)
-		if len(rs) <= 1024 {
-			return s
-		}
-		return string(append(rs[:1024], rune('\u2026')))
+// expectedActualArgs returns the args of FailIf that describe the difference between the expected and actual values.
+// Values of different types are described by their types.
+// Long values are truncated around their first difference.
+func expectedActualArgs(expected any, actual any) []any {
+	if !isNil(actual) && !isNil(expected) && reflect.TypeOf(actual) != reflect.TypeOf(expected) {
+		return []any{"Expected type %v, actual type %v", reflect.TypeOf(expected), reflect.TypeOf(actual)}
 	}
+	expectedArg, actualArg, offsetArg := diffArgs(expected, actual)
+	return []any{"Expected '%v', actual '%v'", expectedArg, actualArg, offsetArg}
+}
+
+// diffArgs returns the args of FailIf that show the expected and actual values side by side,
+// escaped if they look alike and truncated around their first difference,
+// followed by the offset of that difference.
+func diffArgs(expected any, actual any) (expectedArg diffValue, actualArg diffValue, offsetArg diffOffset) {
+	expectedStr, actualStr := render(expected), render(actual)
+	return diffValue{s: expectedStr, other: actualStr},
+		diffValue{s: actualStr, other: expectedStr},
+		diffOffset{expected: expectedStr, actual: actualStr}
+}
+
+// value is the string representation of a value shown in a failure message.
+// It is truncated when the message is formatted, as per the options of the assertion.
+type value string
+
+// v converts o to its string representation to be shown in a failure message.
+func v(o any) value {
+	return value(render(o))
+}
+
+// render converts o to a string, preferring its text marshaling or its String method.
+func render(o any) string {
 	if s, ok := o.(string); ok {
-		return truncate(s)
+		return s
 	}
 	if tm, ok := o.(encoding.TextMarshaler); ok && !isNil(tm) {
 		if txt, err := tm.MarshalText(); err == nil {
-			return truncate(string(txt))
+			return string(txt)
 		}
 	}
 	if s, ok := o.(fmt.Stringer); ok && !isNil(s) {
-		return truncate(s.String())
+		return s.String()
 	}
-	return truncate(fmt.Sprintf("%v", o))
+	return fmt.Sprintf("%v", o)
 }
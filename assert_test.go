@@ -634,13 +634,13 @@ func Test_Stringer(t *testing.T) {
 		t.FailNow()
 	}
 
-	if !Equal(mt, "Stringer", v(&stringer{})) || mt.Failed() {
+	if !Equal(mt, "Stringer", render(&stringer{})) || mt.Failed() {
 		t.FailNow()
 	}
-	if Equal(mt, "Stringer", v((*stringer)(nil))) || mt.Passed() {
+	if Equal(mt, "Stringer", render((*stringer)(nil))) || mt.Passed() {
 		t.FailNow()
 	}
-	if !Equal(mt, "<nil>", v((*stringer)(nil))) || mt.Failed() {
+	if !Equal(mt, "<nil>", render((*stringer)(nil))) || mt.Failed() {
 		t.FailNow()
 	}
 }
@@ -665,13 +665,13 @@ func Test_TextMarshaler(t *testing.T) {
 		t.FailNow()
 	}
 
-	if !Equal(mt, "TextMarshaler", v(&textMarshaler{})) || mt.Failed() {
+	if !Equal(mt, "TextMarshaler", render(&textMarshaler{})) || mt.Failed() {
 		t.FailNow()
 	}
-	if Equal(mt, "TextMarshaler", v((*textMarshaler)(nil))) || mt.Passed() {
+	if Equal(mt, "TextMarshaler", render((*textMarshaler)(nil))) || mt.Passed() {
 		t.FailNow()
 	}
-	if !Equal(mt, "<nil>", v((*textMarshaler)(nil))) || mt.Failed() {
+	if !Equal(mt, "<nil>", render((*textMarshaler)(nil))) || mt.Failed() {
 		t.FailNow()
 	}
 }
@@ -772,20 +772,20 @@ func Test_EdgeCases(t *testing.T) {
 
 	// Test very long strings truncation in v()
 	longStr := string(make([]byte, 2000))
-	formatted := v(longStr)
+	formatted := formatMessage([]any{v(longStr)}, options{maxLen: defaultMaxLen})
 	if len([]rune(formatted)) > 1025 {
 		t.FailNow()
 	}
 
 	// Test v() with nil stringer
 	var nilStringer *stringer
-	if v(nilStringer) != "<nil>" {
+	if render(nilStringer) != "<nil>" {
 		t.FailNow()
 	}
 
 	// Test v() with nil text marshaler
 	var nilMarshaler *textMarshaler
-	if v(nilMarshaler) != "<nil>" {
+	if render(nilMarshaler) != "<nil>" {
 		t.FailNow()
 	}
 
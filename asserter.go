@@ -16,79 +16,174 @@ limitations under the License.
 
 package testarossa
 
+import (
+	"log/slog"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
 type Asserter struct {
-	t TestingT
+	t       TestingT
+	opts    []Option
+	casePos *frame
+	labels  []Label
+	failed  atomic.Bool
+
+	hooks    []func(f Failure)
+	hooksMux sync.Mutex
 }
 
 func For(t TestingT) *Asserter {
+	// Create the state of the test before the test registers cleanup functions, so that it outlives them
+	stateOf(t)
 	return &Asserter{t: t}
 }
 
+// WithOptions returns an Asserter for the same test that applies the options to all its assertions.
+func (tt *Asserter) WithOptions(opts ...Option) *Asserter {
+	return &Asserter{
+		t:    tt,
+		opts: opts,
+	}
+}
+
+/*
+Run runs the function as a subtest of the test, if the test supports subtests as *testing.T and *testing.B do.
+The Asserter given to the function carries over the options, labels and failure hooks of this Asserter.
+Run returns true if the subtest passed.
+
+	tt.Run("empty", func(tt *testarossa.Asserter) {
+		tt.Parallel()
+		tt.Zero(len(""))
+	})
+*/
+func (tt *Asserter) Run(name string, fn func(tt *Asserter)) bool {
+	return runSubtest(tt, name, func(t TestingT) {
+		fn(tt.derive(t))
+	})
+}
+
+// Parallel signals that the test is to be run in parallel with other parallel tests.
+// It has no effect if the test does not support it, as is the case with *testing.B.
+func (tt *Asserter) Parallel() {
+	if p, ok := underlyingT(tt).(interface{ Parallel() }); ok {
+		p.Parallel()
+	}
+}
+
+// derive returns an Asserter for a subtest that carries over the options, labels and failure hooks of this Asserter.
+func (tt *Asserter) derive(t TestingT) *Asserter {
+	child := &Asserter{
+		t:       t,
+		casePos: tt.casePos,
+		labels:  labelsOfT(tt),
+		hooks:   hooksOf(tt),
+	}
+	for parent := tt; parent != nil; {
+		child.opts = append(slices.Clone(parent.opts), child.opts...)
+		if child.casePos == nil {
+			child.casePos = parent.casePos
+		}
+		parent, _ = parent.t.(*Asserter)
+	}
+	return child
+}
+
+// applyOptions applies the options of the Asserter, and of the Asserters it is derived from.
+func (tt *Asserter) applyOptions(opts *options) {
+	if parent, ok := tt.t.(*Asserter); ok {
+		parent.applyOptions(opts)
+	}
+	for _, opt := range tt.opts {
+		opt(opts)
+	}
+}
+
+// Fail marks the test as failed.
+// Asserter implements TestingT so that it can be passed to custom assertions.
+func (tt *Asserter) Fail() {
+	tt.failed.Store(true)
+	tt.t.Fail()
+}
+
+// FailNow marks the test as failed and stops its execution.
+func (tt *Asserter) FailNow() {
+	tt.failed.Store(true)
+	tt.t.FailNow()
+}
+
+// Name returns the name of the test.
+func (tt *Asserter) Name() string {
+	return tt.t.Name()
+}
+
 // Error fails the test if err is nil.
 func (tt *Asserter) Error(err error, args ...any) bool {
-	return Error(tt.t, err, args...)
+	return Error(tt, err, args...)
 }
 
 // NoError fails the test if err is not nil.
 func (tt *Asserter) NoError(err error, args ...any) bool {
-	return NoError(tt.t, err, args...)
+	return NoError(tt, err, args...)
 }
 
 // Equal fails the test if the two values are not equal.
 // Note: the expected value comes before the actual value in the argument list.
 func (tt *Asserter) Equal(expected any, actual any, args ...any) bool {
-	return Equal(tt.t, expected, actual, args...)
+	return Equal(tt, expected, actual, args...)
 }
 
 // NotEqual fails the test if the two values are equal.
 // Note: the expected value comes before the actual value in the argument list.
 func (tt *Asserter) NotEqual(expected any, actual any, args ...any) bool {
-	return NotEqual(tt.t, expected, actual, args...)
+	return NotEqual(tt, expected, actual, args...)
 }
 
 // Zero fails the test if the value is not 0.
 func (tt *Asserter) Zero(actual any, args ...any) bool {
-	return Zero(tt.t, actual, args...)
+	return Zero(tt, actual, args...)
 }
 
 // NotZero fails the test if the value is the 0 value of its type.
 func (tt *Asserter) NotZero(actual any, args ...any) bool {
-	return NotZero(tt.t, actual, args...)
+	return NotZero(tt, actual, args...)
 }
 
 // True fails the test if the condition is false.
 func (tt *Asserter) True(condition bool, args ...any) bool {
-	return True(tt.t, condition, args...)
+	return True(tt, condition, args...)
 }
 
 // False fails the test if the condition is true.
 func (tt *Asserter) False(condition bool, args ...any) bool {
-	return False(tt.t, condition, args...)
+	return False(tt, condition, args...)
 }
 
 // Contains fails the test if the string does not contain a substring.
 func (tt *Asserter) Contains(whole any, sub any, args ...any) bool {
-	return Contains(tt.t, whole, sub, args...)
+	return Contains(tt, whole, sub, args...)
 }
 
 // NotContains fails the test if the string contain a substring.
 func (tt *Asserter) NotContains(whole any, sub any, args ...any) bool {
-	return NotContains(tt.t, whole, sub, args...)
+	return NotContains(tt, whole, sub, args...)
 }
 
-// Len fails the test if the length of the string, slice, array, map or chan does not match the expected len.
+// Len fails the test if the length of the string, slice, array, map, chan, iter.Seq or iter.Seq2 does not match the expected len.
 func (tt *Asserter) Len(obj any, length int, args ...any) bool {
-	return Len(tt.t, obj, length, args...)
+	return Len(tt, obj, length, args...)
 }
 
 // Nil fails the test if the object is not nil.
 func (tt *Asserter) Nil(obj any, args ...any) bool {
-	return Nil(tt.t, obj, args...)
+	return Nil(tt, obj, args...)
 }
 
 // NotNil fails the test if the object is nil.
 func (tt *Asserter) NotNil(obj any, args ...any) bool {
-	return NotNil(tt.t, obj, args...)
+	return NotNil(tt, obj, args...)
 }
 
 /*
@@ -99,7 +194,7 @@ Note: the expected value comes after the actual value in each pair.
 	tt.Expect(err, nil, result, 4321)
 */
 func (tt *Asserter) Expect(actualExpectedPairs ...any) bool {
-	return Expect(tt.t, actualExpectedPairs...)
+	return Expect(tt, actualExpectedPairs...)
 }
 
 /*
@@ -113,7 +208,7 @@ Examples:
 	tt.HTMLMatch(html, `TR TD INPUT[name="x"]`, `[0-9]+``)
 */
 func (tt *Asserter) HTMLMatch(htmlBody []byte, cssSelectorQuery string, innerTextRegExp string, args ...any) bool {
-	return HTMLMatch(tt.t, htmlBody, cssSelectorQuery, innerTextRegExp, args...)
+	return HTMLMatch(tt, htmlBody, cssSelectorQuery, innerTextRegExp, args...)
 }
 
 /*
@@ -127,15 +222,177 @@ Examples:
 	HTMLNotMatch(t, html, `TR TD INPUT[name="x"]`, `[0-9]+``)
 */
 func (tt *Asserter) HTMLNotMatch(htmlBody []byte, cssSelectorQuery string, innerTextRegExp string, args ...any) bool {
-	return HTMLNotMatch(tt.t, htmlBody, cssSelectorQuery, innerTextRegExp, args...)
+	return HTMLNotMatch(tt, htmlBody, cssSelectorQuery, innerTextRegExp, args...)
 }
 
 // Match fails the test if a string doesn't match a regular expression.
 func (tt *Asserter) Match(whole string, regexpStr string, args ...any) bool {
-	return Match(tt.t, whole, regexpStr, args...)
+	return Match(tt, whole, regexpStr, args...)
 }
 
 // NotMatch fails the test if a string matches a regular expression.
 func (tt *Asserter) NotMatch(whole string, regexpStr string, args ...any) bool {
-	return NotMatch(tt.t, whole, regexpStr, args...)
+	return NotMatch(tt, whole, regexpStr, args...)
+}
+
+// ElementsMatch fails the test if the two slices or arrays do not hold the same elements, irrespective of their order.
+// Note: the expected value comes before the actual value in the argument list.
+func (tt *Asserter) ElementsMatch(expected any, actual any, args ...any) bool {
+	return ElementsMatch(tt, expected, actual, args...)
+}
+
+// Subset fails the test if any of the elements of the subset slice or array are not in the superset slice or array.
+func (tt *Asserter) Subset(superset any, subset any, args ...any) bool {
+	return Subset(tt, superset, subset, args...)
+}
+
+// NotSubset fails the test if all the elements of the subset slice or array are in the superset slice or array.
+func (tt *Asserter) NotSubset(superset any, subset any, args ...any) bool {
+	return NotSubset(tt, superset, subset, args...)
+}
+
+// ContainsAll fails the test if the slice or array does not contain each of the elements at least once.
+func (tt *Asserter) ContainsAll(whole any, elements any, args ...any) bool {
+	return ContainsAll(tt, whole, elements, args...)
+}
+
+// ContainsAny fails the test if the slice or array does not contain at least one of the elements.
+func (tt *Asserter) ContainsAny(whole any, elements any, args ...any) bool {
+	return ContainsAny(tt, whole, elements, args...)
+}
+
+// ContainsEntry fails the test if the map does not contain the key, or if the value at that key is not equal to the expected value.
+func (tt *Asserter) ContainsEntry(m any, key any, value any, args ...any) bool {
+	return ContainsEntry(tt, m, key, value, args...)
+}
+
+// MapSubset fails the test if any of the keys of the subset map is missing from the superset map,
+// or if the values at that key are not equal.
+func (tt *Asserter) MapSubset(superset any, subset any, args ...any) bool {
+	return MapSubset(tt, superset, subset, args...)
+}
+
+// KeysEqual fails the test if the keys of the map are not exactly the given keys, irrespective of their order.
+func (tt *Asserter) KeysEqual(m any, keys ...any) bool {
+	return KeysEqual(tt, m, keys...)
+}
+
+// Unique fails the test if the slice or array contains duplicate elements.
+func (tt *Asserter) Unique(slice any, args ...any) bool {
+	return Unique(tt, slice, args...)
+}
+
+// ContainsInOrder fails the test if the subs do not appear in the whole in the given relative order.
+func (tt *Asserter) ContainsInOrder(whole any, subs ...any) bool {
+	return ContainsInOrder(tt, whole, subs...)
+}
+
+// Receives fails the test if a value is not immediately available to be received from the channel.
+// The received value is returned for further assertions.
+func (tt *Asserter) Receives(ch any, args ...any) (value any, ok bool) {
+	return Receives(tt, ch, args...)
+}
+
+// ReceivesWithin fails the test if a value is not received from the channel within the timeout.
+// The received value is returned for further assertions.
+func (tt *Asserter) ReceivesWithin(ch any, timeout time.Duration, args ...any) (value any, ok bool) {
+	return ReceivesWithin(tt, ch, timeout, args...)
+}
+
+// ReceivesEqual fails the test if a value is not received from the channel within the timeout,
+// or if the received value is not equal to the expected value.
+func (tt *Asserter) ReceivesEqual(ch any, expected any, timeout time.Duration, args ...any) bool {
+	return ReceivesEqual(tt, ch, expected, timeout, args...)
+}
+
+// NoReceive fails the test if a value is received from the channel, or if the channel is closed, within the window.
+func (tt *Asserter) NoReceive(ch any, window time.Duration, args ...any) bool {
+	return NoReceive(tt, ch, window, args...)
+}
+
+// Closed fails the test if the channel is not closed.
+func (tt *Asserter) Closed(ch any, args ...any) bool {
+	return Closed(tt, ch, args...)
+}
+
+// Drains fails the test if the number of values immediately available to be received from the channel is not n.
+func (tt *Asserter) Drains(ch any, n int, args ...any) bool {
+	return Drains(tt, ch, n, args...)
+}
+
+// SkipIf skips the test if the condition is met.
+// It returns true if the test is to be skipped.
+func (tt *Asserter) SkipIf(condition bool, args ...any) bool {
+	if h, ok := underlyingT(tt).(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	return skipIf(tt, condition, args)
+}
+
+// SkipUnless skips the test unless the condition is met.
+// It returns true if the test is to be skipped.
+func (tt *Asserter) SkipUnless(condition bool, args ...any) bool {
+	if h, ok := underlyingT(tt).(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	return skipIf(tt, !condition, args)
+}
+
+// NoGoroutineLeaks takes a snapshot of the running goroutines and returns a function that fails the test
+// if goroutines that were started after the snapshot are still running.
+func (tt *Asserter) NoGoroutineLeaks(ignore ...string) func() {
+	return NoGoroutineLeaks(tt, ignore...)
+}
+
+// NoLeaks takes a snapshot of resources and returns a function that fails the test
+// if resources that were acquired after the snapshot are still held.
+func (tt *Asserter) NoLeaks(checks ...LeakCheck) func() {
+	return NoLeaks(tt, checks...)
+}
+
+// NoSideEffects takes a snapshot of global state and returns a function that fails the test if any of it changed.
+func (tt *Asserter) NoSideEffects(watch ...Watch) func() {
+	return NoSideEffects(tt, watch...)
+}
+
+// LogContains fails the test if no captured record is at the level, with a message that matches the regular expression
+// and with all of the attrs.
+func (tt *Asserter) LogContains(logs *Logs, level slog.Level, msgRegexp string, attrs ...any) bool {
+	return LogContains(tt, logs, level, msgRegexp, attrs...)
+}
+
+// NoLogsAbove fails the test if any captured record is at a level above the level.
+func (tt *Asserter) NoLogsAbove(logs *Logs, level slog.Level, args ...any) bool {
+	return NoLogsAbove(tt, logs, level, args...)
+}
+
+// LogCount fails the test if the number of captured records at the level, with a message that matches the regular expression,
+// is not as expected.
+func (tt *Asserter) LogCount(logs *Logs, level slog.Level, msgRegexp string, expected int, args ...any) bool {
+	return LogCount(tt, logs, level, msgRegexp, expected, args...)
+}
+
+// CaptureOutput runs the function and returns what it wrote to stdout and stderr.
+func (tt *Asserter) CaptureOutput(fn func()) Output {
+	return CaptureOutput(tt, fn)
+}
+
+// OutputEqual fails the test if what the function writes to stdout is not equal to the expected string.
+func (tt *Asserter) OutputEqual(expected string, fn func(), args ...any) bool {
+	return OutputEqual(tt, expected, fn, args...)
+}
+
+// OutputContains fails the test if what the function writes to stdout does not contain the substring.
+func (tt *Asserter) OutputContains(substr string, fn func(), args ...any) bool {
+	return OutputContains(tt, substr, fn, args...)
+}
+
+// RequireAssertions fails the test if it completes without evaluating any assertions.
+func (tt *Asserter) RequireAssertions() {
+	RequireAssertions(tt)
+}
+
+// OnFailure registers a hook that is called whenever an assertion of this Asserter, or of the Asserters derived from it, fails.
+func (tt *Asserter) OnFailure(hook func(f Failure)) {
+	OnFailure(tt, hook)
 }
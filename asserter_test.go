@@ -18,7 +18,9 @@ package testarossa
 
 import (
 	"errors"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestAsserter_All(t *testing.T) {
@@ -78,6 +80,66 @@ func TestAsserter_All(t *testing.T) {
 	if tt.NotMatch("foo bar", `\sb`) || mt.Passed() {
 		t.FailNow()
 	}
+	if !tt.ElementsMatch([]int{1, 2, 3}, []int{3, 2, 1}) || mt.Failed() {
+		t.FailNow()
+	}
+	if !tt.Subset([]int{1, 2, 3}, []int{3, 2}) || mt.Failed() {
+		t.FailNow()
+	}
+	if !tt.NotSubset([]int{1, 2, 3}, []int{4}) || mt.Failed() {
+		t.FailNow()
+	}
+	if !tt.ContainsAll([]int{1, 2, 3}, []int{1, 3}) || mt.Failed() {
+		t.FailNow()
+	}
+	if !tt.ContainsAny([]int{1, 2, 3}, []int{4, 3}) || mt.Failed() {
+		t.FailNow()
+	}
+	if !tt.ContainsEntry(map[string]int{"a": 1}, "a", 1) || mt.Failed() {
+		t.FailNow()
+	}
+	if !tt.MapSubset(map[string]int{"a": 1, "b": 2}, map[string]int{"b": 2}) || mt.Failed() {
+		t.FailNow()
+	}
+	if !tt.KeysEqual(map[string]int{"a": 1, "b": 2}, "b", "a") || mt.Failed() {
+		t.FailNow()
+	}
+	if !tt.Unique([]int{1, 2, 3}) || mt.Failed() {
+		t.FailNow()
+	}
+	if !tt.ContainsInOrder("foo bar baz", "foo", "baz") || mt.Failed() {
+		t.FailNow()
+	}
+}
+
+func TestAsserter_Chan(t *testing.T) {
+	mt := &MockTestingT{}
+	tt := For(mt)
+
+	ch := make(chan int, 4)
+	ch <- 1
+	ch <- 2
+	ch <- 3
+	if _, ok := tt.Receives(ch); !ok || mt.Failed() {
+		t.FailNow()
+	}
+	if _, ok := tt.ReceivesWithin(ch, time.Second); !ok || mt.Failed() {
+		t.FailNow()
+	}
+	if !tt.ReceivesEqual(ch, 3, time.Second) || mt.Failed() {
+		t.FailNow()
+	}
+	if !tt.NoReceive(ch, time.Millisecond) || mt.Failed() {
+		t.FailNow()
+	}
+	ch <- 4
+	if !tt.Drains(ch, 1) || mt.Failed() {
+		t.FailNow()
+	}
+	close(ch)
+	if !tt.Closed(ch) || mt.Failed() {
+		t.FailNow()
+	}
 }
 
 func TestAsserter_HTML(t *testing.T) {
@@ -123,3 +185,58 @@ func TestAsserter_HTML(t *testing.T) {
 		t.FailNow()
 	}
 }
+
+func TestAsserter_Run(t *testing.T) {
+	tt := For(t).WithOptions(MaxLen(10))
+
+	var names []string
+	var maxLens []int
+	passed := tt.Run("parent", func(tt *Asserter) {
+		names = append(names, tt.Name())
+		maxLens = append(maxLens, optionsOf(tt, nil).maxLen)
+		tt.WithOptions(EscapeAlways()).Run("child", func(tt *Asserter) {
+			names = append(names, tt.Name())
+			opts := optionsOf(tt, nil)
+			maxLens = append(maxLens, opts.maxLen)
+			if !opts.escape {
+				t.Error("Options not carried over")
+			}
+		})
+	})
+	if !passed {
+		t.FailNow()
+	}
+	if len(names) != 2 || names[0] != "TestAsserter_Run/parent" || names[1] != "TestAsserter_Run/parent/child" {
+		t.FailNow()
+	}
+	if len(maxLens) != 2 || maxLens[0] != 10 || maxLens[1] != 10 {
+		t.FailNow()
+	}
+
+	// Without support for subtests
+	mt := &MockTestingT{}
+	tt = For(mt)
+	if tt.Run("fail", func(tt *Asserter) { tt.True(false) }) || mt.Passed() {
+		t.FailNow()
+	}
+	if !tt.Run("pass", func(tt *Asserter) { tt.True(true) }) || mt.Failed() {
+		t.FailNow()
+	}
+	tt.Parallel()
+}
+
+func TestAsserter_Parallel(t *testing.T) {
+	tt := For(t)
+	var count atomic.Int32
+	tt.Run("group", func(tt *Asserter) {
+		for _, name := range []string{"a", "b", "c"} {
+			tt.Run(name, func(tt *Asserter) {
+				tt.Parallel()
+				count.Add(1)
+			})
+		}
+	})
+	if count.Load() != 3 {
+		t.FailNow()
+	}
+}
@@ -18,6 +18,7 @@ package testarossa
 
 import (
 	"errors"
+	"strings"
 	"testing"
 )
 
@@ -99,3 +100,69 @@ func Benchmark_Len(b *testing.B) {
 		Len(mt, slice, 5)
 	}
 }
+
+func Benchmark_EqualSliceBoxed(b *testing.B) {
+	b.ReportAllocs()
+	mt := &MockTestingT{}
+	var slice1, slice2 any = []int{1, 2, 3, 4, 5}, []int{1, 2, 3, 4, 5}
+	for b.Loop() {
+		Equal(mt, slice1, slice2)
+	}
+}
+
+func Benchmark_ContainsSliceBoxed(b *testing.B) {
+	b.ReportAllocs()
+	mt := &MockTestingT{}
+	var slice any = []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	for b.Loop() {
+		Contains(mt, slice, 5)
+	}
+}
+
+func Benchmark_LenBoxed(b *testing.B) {
+	b.ReportAllocs()
+	mt := &MockTestingT{}
+	var slice any = []int{1, 2, 3, 4, 5}
+	for b.Loop() {
+		Len(mt, slice, 5)
+	}
+}
+
+func Benchmark_EqualString(b *testing.B) {
+	b.ReportAllocs()
+	mt := &MockTestingT{}
+	var s1, s2 any = strings.Repeat("x", 4096), strings.Repeat("x", 4096)
+	for b.Loop() {
+		Equal(mt, s1, s2)
+	}
+}
+
+func Test_PassingAssertionsDoNotAllocate(t *testing.T) {
+	mt := &MockTestingT{}
+	var slice1, slice2 any = []int{1, 2, 3, 4, 5}, []int{1, 2, 3, 4, 5}
+	var big1, big2 any = strings.Repeat("x", 4096), strings.Repeat("x", 4096)
+	var values any = []int{1000, 2000, 3000}
+	var sub any = 2000
+	testCases := map[string]func(){
+		"Equal":          func() { Equal(mt, 1, 1) },
+		"EqualSlice":     func() { Equal(mt, slice1, slice2) },
+		"EqualString":    func() { Equal(mt, big1, big2) },
+		"NotEqual":       func() { NotEqual(mt, 1, 2) },
+		"ContainsString": func() { Contains(mt, "hello world", "world") },
+		"ContainsSlice":  func() { Contains(mt, values, sub) },
+		"NotContains":    func() { NotContains(mt, "hello world", "xyz") },
+		"Len":            func() { Len(mt, slice1, 5) },
+		"LenString":      func() { Len(mt, big1, 4096) },
+		"NoError":        func() { NoError(mt, nil) },
+		"Nil":            func() { Nil(mt, nil) },
+		"True":           func() { True(mt, true) },
+		"Zero":           func() { Zero(mt, 0) },
+		"FailIfError":    func() { FailIfError(mt, nil) },
+	}
+	for name, fn := range testCases {
+		allocs := testing.AllocsPerRun(100, fn)
+		if allocs != 0 || mt.Failed() {
+			t.Errorf("%s: %v allocations", name, allocs)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2024-2025 Microbus LLC and various contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testarossa
+
+import (
+	"reflect"
+	"time"
+)
+
+// Receives fails the test if a value is not immediately available to be received from the channel.
+// The received value is returned for further assertions.
+func Receives(t TestingT, ch any, args ...any) (value any, ok bool) {
+	return ReceivesWithin(t, ch, 0, args...)
+}
+
+/*
+ReceivesWithin fails the test if a value is not received from the channel within the timeout.
+The received value is returned for further assertions.
+
+	event, ok := ReceivesWithin(t, events, time.Second)
+*/
+func ReceivesWithin(t TestingT, ch any, timeout time.Duration, args ...any) (value any, ok bool) {
+	countAssertion(t)
+	return receivesWithin(t, ch, timeout, args...)
+}
+
+// receivesWithin fails the test if a value is not received from the channel within the timeout, without counting an assertion.
+func receivesWithin(t TestingT, ch any, timeout time.Duration, args ...any) (value any, ok bool) {
+	chValue, ok := recvChan(t, ch, args)
+	if !ok {
+		return nil, false
+	}
+	received, recvOK, timedOut, waited := recvWithin(chValue, timeout)
+	if timedOut {
+		msgArgs := []any{"Expected to receive within %v, waited %v", timeout, waited}
+		if timeout <= 0 {
+			msgArgs = []any{"Expected to receive immediately, channel is empty"}
+		}
+		fail(
+			t,
+			true,
+			append(msgArgs, args...)...,
+		)
+		return nil, false
+	}
+	if !recvOK {
+		msgArgs := []any{"Expected to receive, channel closed after %v", waited}
+		fail(
+			t,
+			true,
+			append(msgArgs, args...)...,
+		)
+		return nil, false
+	}
+	return received.Interface(), true
+}
+
+// ReceivesEqual fails the test if a value is not received from the channel within the timeout,
+// or if the received value is not equal to the expected value.
+func ReceivesEqual(t TestingT, ch any, expected any, timeout time.Duration, args ...any) bool {
+	countAssertion(t)
+	actual, ok := receivesWithin(t, ch, timeout, args...)
+	if !ok {
+		return false
+	}
+	return equal(t, expected, actual, args...)
+}
+
+// NoReceive fails the test if a value is received from the channel, or if the channel is closed, within the window.
+func NoReceive(t TestingT, ch any, window time.Duration, args ...any) bool {
+	countAssertion(t)
+	chValue, ok := recvChan(t, ch, args)
+	if !ok {
+		return false
+	}
+	received, recvOK, timedOut, waited := recvWithin(chValue, window)
+	if timedOut {
+		return true
+	}
+	var msgArgs []any
+	if recvOK {
+		msgArgs = []any{"Expected no value within %v, received '%v' after %v", window, v(received.Interface()), waited}
+	} else {
+		msgArgs = []any{"Expected no value within %v, channel closed after %v", window, waited}
+	}
+	fail(
+		t,
+		true,
+		append(msgArgs, args...)...,
+	)
+	return false
+}
+
+// Closed fails the test if the channel is not closed.
+// Values that are still buffered in the channel are considered to keep it open.
+func Closed(t TestingT, ch any, args ...any) bool {
+	countAssertion(t)
+	chValue, ok := recvChan(t, ch, args)
+	if !ok {
+		return false
+	}
+	received, recvOK, timedOut, _ := recvWithin(chValue, 0)
+	if !timedOut && !recvOK {
+		return true
+	}
+	var msgArgs []any
+	if timedOut {
+		msgArgs = []any{"Expected channel to be closed, still open"}
+	} else {
+		msgArgs = []any{"Expected channel to be closed, received '%v'", v(received.Interface())}
+	}
+	fail(
+		t,
+		true,
+		append(msgArgs, args...)...,
+	)
+	return false
+}
+
+// Drains fails the test if the number of values immediately available to be received from the channel is not n.
+// The channel is drained of up to n+1 values in the process.
+func Drains(t TestingT, ch any, n int, args ...any) bool {
+	countAssertion(t)
+	chValue, ok := recvChan(t, ch, args)
+	if !ok {
+		return false
+	}
+	count := 0
+	for count <= n {
+		_, recvOK, timedOut, _ := recvWithin(chValue, 0)
+		if timedOut || !recvOK {
+			break
+		}
+		count++
+	}
+	if count == n {
+		return true
+	}
+	var msgArgs []any
+	if count > n {
+		msgArgs = []any{"Expected to drain %d values, drained more", n}
+	} else {
+		msgArgs = []any{"Expected to drain %d values, drained %d", n, count}
+	}
+	fail(
+		t,
+		true,
+		append(msgArgs, args...)...,
+	)
+	return false
+}
+
+// recvChan returns the reflected channel, failing the test if the object is not a channel that can be received from.
+func recvChan(t TestingT, ch any, args []any) (chValue reflect.Value, ok bool) {
+	if ch == nil {
+		msgArgs := []any{"Nil is not a channel"}
+		fail(
+			t,
+			true,
+			append(msgArgs, args...)...,
+		)
+		return chValue, false
+	}
+	chValue = reflect.ValueOf(ch)
+	if chValue.Kind() != reflect.Chan || chValue.Type().ChanDir()&reflect.RecvDir == 0 {
+		msgArgs := []any{"Type %v is not a channel that can be received from", chValue.Type()}
+		fail(
+			t,
+			true,
+			append(msgArgs, args...)...,
+		)
+		return chValue, false
+	}
+	return chValue, true
+}
+
+// recvWithin receives from the channel, waiting no longer than the timeout.
+// A non-positive timeout does not wait at all.
+// recvOK is false if the channel is closed.
+func recvWithin(chValue reflect.Value, timeout time.Duration) (received reflect.Value, recvOK bool, timedOut bool, waited time.Duration) {
+	cases := []reflect.SelectCase{
+		{Dir: reflect.SelectRecv, Chan: chValue},
+	}
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(timer.C)})
+	} else {
+		cases = append(cases, reflect.SelectCase{Dir: reflect.SelectDefault})
+	}
+	t0 := time.Now()
+	chosen, received, recvOK := reflect.Select(cases)
+	waited = time.Since(t0)
+	if chosen != 0 {
+		return reflect.Value{}, false, true, waited
+	}
+	return received, recvOK, false, waited
+}
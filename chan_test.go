@@ -0,0 +1,149 @@
+/*
+Copyright 2024-2025 Microbus LLC and various contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testarossa
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_ReceivesWithin(t *testing.T) {
+	mt := &MockTestingT{}
+
+	ch := make(chan string, 1)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		ch <- "hello"
+	}()
+	if _, ok := Receives(mt, ch); ok || mt.Passed() {
+		t.FailNow()
+	}
+	value, ok := ReceivesWithin(mt, ch, time.Second)
+	if !ok || mt.Failed() {
+		t.FailNow()
+	}
+	if value != "hello" {
+		t.FailNow()
+	}
+	if _, ok := ReceivesWithin(mt, ch, 10*time.Millisecond); ok || mt.Passed() {
+		t.FailNow()
+	}
+
+	// Receive-only channel
+	var recvOnly <-chan string = ch
+	ch <- "world"
+	if value, ok := ReceivesWithin(mt, recvOnly, time.Second); !ok || value != "world" || mt.Failed() {
+		t.FailNow()
+	}
+
+	close(ch)
+	if _, ok := ReceivesWithin(mt, ch, time.Second); ok || mt.Passed() {
+		t.FailNow()
+	}
+
+	// Not channels
+	if _, ok := Receives(mt, nil); ok || mt.Passed() {
+		t.FailNow()
+	}
+	if _, ok := Receives(mt, 1); ok || mt.Passed() {
+		t.FailNow()
+	}
+	var sendOnly chan<- string = make(chan string)
+	if _, ok := Receives(mt, sendOnly); ok || mt.Passed() {
+		t.FailNow()
+	}
+}
+
+func Test_ReceivesEqual(t *testing.T) {
+	mt := &MockTestingT{}
+
+	ch := make(chan int, 2)
+	ch <- 1
+	ch <- 2
+	if !ReceivesEqual(mt, ch, 1, time.Second) || mt.Failed() {
+		t.FailNow()
+	}
+	if ReceivesEqual(mt, ch, 1, time.Second) || mt.Passed() {
+		t.FailNow()
+	}
+	if ReceivesEqual(mt, ch, 1, 10*time.Millisecond) || mt.Passed() {
+		t.FailNow()
+	}
+}
+
+func Test_NoReceive(t *testing.T) {
+	mt := &MockTestingT{}
+
+	ch := make(chan int, 1)
+	if !NoReceive(mt, ch, 10*time.Millisecond) || mt.Failed() {
+		t.FailNow()
+	}
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		ch <- 1
+	}()
+	if NoReceive(mt, ch, time.Second) || mt.Passed() {
+		t.FailNow()
+	}
+	close(ch)
+	if NoReceive(mt, ch, time.Second) || mt.Passed() {
+		t.FailNow()
+	}
+}
+
+func Test_Closed(t *testing.T) {
+	mt := &MockTestingT{}
+
+	ch := make(chan int, 1)
+	if Closed(mt, ch) || mt.Passed() {
+		t.FailNow()
+	}
+	ch <- 1
+	close(ch)
+	if Closed(mt, ch) || mt.Passed() {
+		t.FailNow()
+	}
+	if !Closed(mt, ch) || mt.Failed() {
+		t.FailNow()
+	}
+}
+
+func Test_Drains(t *testing.T) {
+	mt := &MockTestingT{}
+
+	ch := make(chan int, 8)
+	for i := range 3 {
+		ch <- i
+	}
+	if !Drains(mt, ch, 3) || mt.Failed() {
+		t.FailNow()
+	}
+	if !Drains(mt, ch, 0) || mt.Failed() {
+		t.FailNow()
+	}
+	for i := range 3 {
+		ch <- i
+	}
+	if Drains(mt, ch, 2) || mt.Passed() {
+		t.FailNow()
+	}
+	ch <- 1
+	close(ch)
+	if Drains(mt, ch, 2) || mt.Passed() {
+		t.FailNow()
+	}
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2024-2025 Microbus LLC and various contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testarossa
+
+import (
+	"cmp"
+	"math"
+	"reflect"
+	"time"
+)
+
+// greater fails the test if the actual value is not greater than the threshold.
+// Numbers of any type, strings and time.Time are supported. NaN is not ordered.
+// It backs the ordering methods of Subject.
+func greater(t TestingT, actual any, threshold any, args ...any) bool {
+	countAssertion(t)
+	return compareWith(t, actual, threshold, "greater than", func(c int) bool { return c > 0 }, args)
+}
+
+// greaterOrEqual fails the test if the actual value is less than the threshold.
+// Numbers of any type, strings and time.Time are supported. NaN is not ordered.
+// It backs the ordering methods of Subject.
+func greaterOrEqual(t TestingT, actual any, threshold any, args ...any) bool {
+	countAssertion(t)
+	return compareWith(t, actual, threshold, "greater than or equal to", func(c int) bool { return c >= 0 }, args)
+}
+
+// less fails the test if the actual value is not less than the threshold.
+// Numbers of any type, strings and time.Time are supported. NaN is not ordered.
+// It backs the ordering methods of Subject.
+func less(t TestingT, actual any, threshold any, args ...any) bool {
+	countAssertion(t)
+	return compareWith(t, actual, threshold, "less than", func(c int) bool { return c < 0 }, args)
+}
+
+// lessOrEqual fails the test if the actual value is greater than the threshold.
+// Numbers of any type, strings and time.Time are supported. NaN is not ordered.
+// It backs the ordering methods of Subject.
+func lessOrEqual(t TestingT, actual any, threshold any, args ...any) bool {
+	countAssertion(t)
+	return compareWith(t, actual, threshold, "less than or equal to", func(c int) bool { return c <= 0 }, args)
+}
+
+// compareWith compares the actual value to the threshold and fails the test if the result of the comparison is not accepted.
+// Numbers are compared by value across types, and NaN fails every comparison.
+func compareWith(t TestingT, actual any, threshold any, relation string, accept func(c int) bool, args []any) bool {
+	if reflect.TypeOf(actual) != reflect.TypeOf(threshold) && !(isNumber(actual) && isNumber(threshold)) {
+		msgArgs := []any{"Expected type %v, actual type %v", reflect.TypeOf(threshold), reflect.TypeOf(actual)}
+		fail(
+			t,
+			true,
+			append(msgArgs, args...)...,
+		)
+		return false
+	}
+	c, ordered, ok := compareValues(actual, threshold)
+	if !ok {
+		msgArgs := []any{"Type %v is not ordered", reflect.TypeOf(actual)}
+		fail(
+			t,
+			true,
+			append(msgArgs, args...)...,
+		)
+		return false
+	}
+	if ordered && accept(c) {
+		return true
+	}
+	msgArgs := []any{"Expected '%v' to be " + relation + " '%v'", v(actual), v(threshold)}
+	fail(
+		t,
+		true,
+		append(msgArgs, args...)...,
+	)
+	return false
+}
+
+// isNumber indicates if the value is an integer or a floating point number.
+func isNumber(x any) bool {
+	switch reflect.ValueOf(x).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+/*
+compareValues compares two values of an ordered type.
+Numbers may be of different types.
+It returns a negative number when a < b, a positive number when a > b and zero when a == b.
+It returns false for ordered if either value is NaN, and false for ok if the type is not ordered.
+*/
+func compareValues(a any, b any) (c int, ordered bool, ok bool) {
+	if isNil(a) || isNil(b) {
+		return 0, false, false
+	}
+	if ta, ok := a.(time.Time); ok {
+		tb, ok := b.(time.Time)
+		if !ok {
+			return 0, false, false
+		}
+		return ta.Compare(tb), true, true
+	}
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if va.Kind() == reflect.String && vb.Kind() == reflect.String {
+		return cmp.Compare(va.String(), vb.String()), true, true
+	}
+	if !isNumber(a) || !isNumber(b) {
+		return 0, false, false
+	}
+	isFloat := func(x reflect.Value) bool { return x.Kind() == reflect.Float32 || x.Kind() == reflect.Float64 }
+	isInt := func(x reflect.Value) bool { return x.CanInt() }
+	switch {
+	case isFloat(va) || isFloat(vb):
+		fa, fb := toFloat(va), toFloat(vb)
+		if math.IsNaN(fa) || math.IsNaN(fb) {
+			return 0, false, true
+		}
+		return cmp.Compare(fa, fb), true, true
+	case isInt(va) && isInt(vb):
+		return cmp.Compare(va.Int(), vb.Int()), true, true
+	case !isInt(va) && !isInt(vb):
+		return cmp.Compare(va.Uint(), vb.Uint()), true, true
+	case isInt(va):
+		// Signed against unsigned
+		if va.Int() < 0 {
+			return -1, true, true
+		}
+		return cmp.Compare(uint64(va.Int()), vb.Uint()), true, true
+	default:
+		if vb.Int() < 0 {
+			return 1, true, true
+		}
+		return cmp.Compare(va.Uint(), uint64(vb.Int())), true, true
+	}
+}
+
+// toFloat converts a number to a float64.
+func toFloat(x reflect.Value) float64 {
+	switch {
+	case x.CanFloat():
+		return x.Float()
+	case x.CanInt():
+		return float64(x.Int())
+	}
+	return float64(x.Uint())
+}
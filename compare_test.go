@@ -0,0 +1,133 @@
+/*
+Copyright 2024-2025 Microbus LLC and various contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testarossa
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func Test_Compare(t *testing.T) {
+	mt := &MockTestingT{}
+
+	if !greater(mt, 2, 1) || mt.Failed() {
+		t.FailNow()
+	}
+	if greater(mt, 1, 1) || mt.Passed() {
+		t.FailNow()
+	}
+	if !greaterOrEqual(mt, 1, 1) || mt.Failed() {
+		t.FailNow()
+	}
+	if greaterOrEqual(mt, 0, 1) || mt.Passed() {
+		t.FailNow()
+	}
+	if !less(mt, 1.5, 2.5) || mt.Failed() {
+		t.FailNow()
+	}
+	if less(mt, 2.5, 2.5) || mt.Passed() {
+		t.FailNow()
+	}
+	if !lessOrEqual(mt, uint8(3), uint8(3)) || mt.Failed() {
+		t.FailNow()
+	}
+	if lessOrEqual(mt, uint8(4), uint8(3)) || mt.Passed() {
+		t.FailNow()
+	}
+	if !less(mt, "abc", "abd") || mt.Failed() {
+		t.FailNow()
+	}
+	if !greater(mt, time.Second, time.Millisecond) || mt.Failed() {
+		t.FailNow()
+	}
+	now := time.Now()
+	if !greater(mt, now.Add(time.Second), now) || mt.Failed() {
+		t.FailNow()
+	}
+	if less(mt, now.Add(time.Second), now) || mt.Passed() {
+		t.FailNow()
+	}
+
+	// Numbers of different types
+	if !greater(mt, 2, int64(1)) || mt.Failed() {
+		t.FailNow()
+	}
+	if !greater(mt, 12.5, 0) || mt.Failed() {
+		t.FailNow()
+	}
+	if !less(mt, -1, uint(0)) || mt.Failed() {
+		t.FailNow()
+	}
+	if !greater(mt, uint64(math.MaxUint64), int64(math.MaxInt64)) || mt.Failed() {
+		t.FailNow()
+	}
+	if !greater(mt, uint(0), -1.5) || mt.Failed() {
+		t.FailNow()
+	}
+	if lessOrEqual(mt, float32(2.5), 2) || mt.Passed() {
+		t.FailNow()
+	}
+
+	// NaN is not ordered
+	if less(mt, math.NaN(), 1.0) || mt.Passed() {
+		t.FailNow()
+	}
+	if greaterOrEqual(mt, 1, math.NaN()) || mt.Passed() {
+		t.FailNow()
+	}
+
+	// Mismatched or unordered types
+	if greater(mt, 2, "1") || mt.Passed() {
+		t.FailNow()
+	}
+	if greater(mt, []int{2}, []int{1}) || mt.Passed() {
+		t.FailNow()
+	}
+	if greater(mt, nil, nil) || mt.Passed() {
+		t.FailNow()
+	}
+	if greater(mt, now, nil) || mt.Passed() {
+		t.FailNow()
+	}
+	if less(mt, nil, now) || mt.Passed() {
+		t.FailNow()
+	}
+	if greater(mt, now, "yesterday") || mt.Passed() {
+		t.FailNow()
+	}
+	if For(mt).That(now).IsGreaterThan(nil).Passed() || mt.Passed() {
+		t.FailNow()
+	}
+}
+
+func Test_HasPrefixSuffix(t *testing.T) {
+	mt := &MockTestingT{}
+
+	if !hasPrefix(mt, "hello world", "hello") || mt.Failed() {
+		t.FailNow()
+	}
+	if hasPrefix(mt, "hello world", "world") || mt.Passed() {
+		t.FailNow()
+	}
+	if !hasSuffix(mt, "hello world", "world") || mt.Failed() {
+		t.FailNow()
+	}
+	if hasSuffix(mt, "hello world", "hello") || mt.Passed() {
+		t.FailNow()
+	}
+}
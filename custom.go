@@ -0,0 +1,131 @@
+/*
+Copyright 2024-2025 Microbus LLC and various contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testarossa
+
+import (
+	"runtime"
+)
+
+/*
+AssertionBuilder composes the failure message of a custom assertion so that it is formatted consistently with the built-in assertions.
+The function that calls FailIf or FatalIf on the builder is considered a helper and is omitted from the stack trace,
+so that the failure points at the line that called the custom assertion.
+
+	func PositiveTotal(t testarossa.TestingT, order Order, args ...any) bool {
+		return !testarossa.Assertion(t).
+			Actual(order.Total).
+			Message("Expected a positive total").
+			Message(args...).
+			FailIf(order.Total <= 0)
+	}
+*/
+type AssertionBuilder struct {
+	t           TestingT
+	expected    any
+	actual      any
+	hasExpected bool
+	hasActual   bool
+	msgArgs     []any
+}
+
+// Assertion starts building a custom assertion.
+func Assertion(t TestingT) *AssertionBuilder {
+	return &AssertionBuilder{t: t}
+}
+
+// Expected sets the expected value to show in the failure message.
+func (ab *AssertionBuilder) Expected(expected any) *AssertionBuilder {
+	ab.expected = expected
+	ab.hasExpected = true
+	return ab
+}
+
+// Actual sets the actual value to show in the failure message.
+func (ab *AssertionBuilder) Actual(actual any) *AssertionBuilder {
+	ab.actual = actual
+	ab.hasActual = true
+	return ab
+}
+
+// Message appends to the failure message.
+// The args are interpreted the same way as the args of FailIf.
+func (ab *AssertionBuilder) Message(args ...any) *AssertionBuilder {
+	ab.msgArgs = append(ab.msgArgs, args...)
+	return ab
+}
+
+// FailIf fails the test if the condition is met.
+// It returns back the result of evaluating the condition.
+func (ab *AssertionBuilder) FailIf(condition bool) bool {
+	countAssertion(ab.t)
+	if !condition {
+		return false
+	}
+	return failIf(ab.t, true, callerHelper(), ab.args())
+}
+
+// FatalIf fails the test and stops further execution if the condition is met.
+// It returns back the result of evaluating the condition.
+func (ab *AssertionBuilder) FatalIf(condition bool) bool {
+	countAssertion(ab.t)
+	if !condition {
+		return false
+	}
+	failIf(ab.t, true, callerHelper(), ab.args())
+	ab.t.FailNow()
+	return true
+}
+
+// args returns the args of FailIf that describe the failure.
+func (ab *AssertionBuilder) args() []any {
+	var msgArgs []any
+	switch {
+	case ab.hasExpected && ab.hasActual:
+		msgArgs = expectedActualArgs(ab.expected, ab.actual)
+	case ab.hasExpected:
+		msgArgs = []any{"Expected '%v'", v(ab.expected)}
+	case ab.hasActual:
+		msgArgs = []any{"Actual '%v'", v(ab.actual)}
+	}
+	return append(msgArgs, ab.msgArgs...)
+}
+
+// callerHelper returns the name of the function that called the caller of callerHelper,
+// unless it is a test function.
+func callerHelper() []string {
+	pc, _, _, ok := runtime.Caller(2)
+	if !ok {
+		return nil
+	}
+	funcName := runtime.FuncForPC(pc).Name()
+	if isTestFunc(funcName) {
+		return nil
+	}
+	return []string{funcName}
+}
+
+// FormatValue formats a value the way the built-in assertions show it in failure messages.
+func FormatValue(value any) string {
+	return defaultOptions().render(v(value)).(string)
+}
+
+// FormatExpectedActual formats an expected and an actual value the way the built-in assertions show them side by side in failure messages.
+// Long values are truncated around their first difference, and values that look alike are escaped.
+func FormatExpectedActual(expected any, actual any) (expectedStr string, actualStr string) {
+	expectedStr, actualStr, _, _ = defaultOptions().formatPair(render(expected), render(actual))
+	return expectedStr, actualStr
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2024-2025 Microbus LLC and various contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testarossa
+
+import (
+	"runtime"
+	"testing"
+)
+
+type RecordingTestingT struct {
+	MockTestingT
+	file    string
+	line    int
+	message string
+	labels  []Label
+}
+
+func (rt *RecordingTestingT) RecordFailure(file string, line int, message string, labels []Label) {
+	rt.file = file
+	rt.line = line
+	rt.message = message
+	rt.labels = labels
+}
+
+func positiveTotal(t TestingT, total int, args ...any) bool {
+	return !Assertion(t).
+		Actual(total).
+		Message("Expected a positive total").
+		Message(args...).
+		FailIf(total <= 0)
+}
+
+func Test_Assertion(t *testing.T) {
+	rt := &RecordingTestingT{}
+
+	if !positiveTotal(rt, 1) || rt.Failed() {
+		t.FailNow()
+	}
+	_, file, line, _ := runtime.Caller(0)
+	if positiveTotal(rt, -1, "For order %d", 123) || rt.Passed() {
+		t.FailNow()
+	}
+	// The frame of the custom assertion is omitted
+	if rt.file != file || rt.line != line+1 {
+		t.FailNow()
+	}
+	if rt.message != "Actual '-1'\nExpected a positive total\nFor order 123" {
+		t.FailNow()
+	}
+
+	if !Assertion(rt).Expected(1).Actual(2).FailIf(true) || rt.Passed() {
+		t.FailNow()
+	}
+	if rt.message != "Expected '1', actual '2'" {
+		t.FailNow()
+	}
+	if !Assertion(rt).Expected(1).Actual("1").FailIf(true) || rt.Passed() {
+		t.FailNow()
+	}
+	if rt.message != "Expected type int, actual type string" {
+		t.FailNow()
+	}
+	if !Assertion(rt).Expected(1).Message("Bad").FatalIf(true) || rt.Passed() {
+		t.FailNow()
+	}
+	if rt.message != "Expected '1'\nBad" {
+		t.FailNow()
+	}
+	// The frame of a test function is not omitted
+	_, _, line, _ = runtime.Caller(0)
+	if !Assertion(rt).FailIf(true) || rt.Passed() {
+		t.FailNow()
+	}
+	if rt.file != file || rt.line != line+1 {
+		t.FailNow()
+	}
+	if Assertion(rt).FatalIf(false) || rt.Failed() {
+		t.FailNow()
+	}
+}
+
+func Test_FormatValue(t *testing.T) {
+	if FormatValue(&stringer{x: "X"}) != "StringerX" {
+		t.FailNow()
+	}
+	e, a := FormatExpectedActual(1, "2")
+	if e != "1" || a != "2" {
+		t.FailNow()
+	}
+}
@@ -0,0 +1,216 @@
+/*
+Copyright 2024-2025 Microbus LLC and various contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testarossa
+
+import (
+	"reflect"
+)
+
+// ElementsMatch fails the test if the two slices or arrays do not hold the same elements, irrespective of their order.
+// Duplicate elements must appear the same number of times in both.
+// Note: the expected value comes before the actual value in the argument list.
+func ElementsMatch(t TestingT, expected any, actual any, args ...any) bool {
+	countAssertion(t)
+	expectedElems, ok := elementsOf(t, expected, args)
+	if !ok {
+		return false
+	}
+	actualElems, ok := elementsOf(t, actual, args)
+	if !ok {
+		return false
+	}
+	missing, extra := diffElements(expectedElems, actualElems)
+	if len(missing) == 0 && len(extra) == 0 {
+		return true
+	}
+	expectedArg, actualArg, offsetArg := diffArgs(expected, actual)
+	msgArgs := []any{"Expected elements '%v', actual '%v'", expectedArg, actualArg, offsetArg}
+	if len(missing) > 0 {
+		msgArgs = append(msgArgs, "Missing '%v'", v(missing))
+	}
+	if len(extra) > 0 {
+		msgArgs = append(msgArgs, "Extra '%v'", v(extra))
+	}
+	fail(
+		t,
+		true,
+		append(msgArgs, args...)...,
+	)
+	return false
+}
+
+// Subset fails the test if any of the elements of the subset slice or array are not in the superset slice or array.
+// Duplicate elements in the subset must appear at least as many times in the superset.
+func Subset(t TestingT, superset any, subset any, args ...any) bool {
+	countAssertion(t)
+	supersetElems, ok := elementsOf(t, superset, args)
+	if !ok {
+		return false
+	}
+	subsetElems, ok := elementsOf(t, subset, args)
+	if !ok {
+		return false
+	}
+	missing, _ := diffElements(subsetElems, supersetElems)
+	if len(missing) == 0 {
+		return true
+	}
+	msgArgs := []any{"Expected '%v' to be a subset of '%v'", v(subset), v(superset), "Missing '%v'", v(missing)}
+	fail(
+		t,
+		true,
+		append(msgArgs, args...)...,
+	)
+	return false
+}
+
+// NotSubset fails the test if all the elements of the subset slice or array are in the superset slice or array.
+// Duplicate elements in the subset must appear at least as many times in the superset.
+func NotSubset(t TestingT, superset any, subset any, args ...any) bool {
+	countAssertion(t)
+	supersetElems, ok := elementsOf(t, superset, args)
+	if !ok {
+		return false
+	}
+	subsetElems, ok := elementsOf(t, subset, args)
+	if !ok {
+		return false
+	}
+	missing, _ := diffElements(subsetElems, supersetElems)
+	if len(missing) > 0 {
+		return true
+	}
+	msgArgs := []any{"Expected '%v' not to be a subset of '%v'", v(subset), v(superset)}
+	fail(
+		t,
+		true,
+		append(msgArgs, args...)...,
+	)
+	return false
+}
+
+// ContainsAll fails the test if the slice or array does not contain each of the elements at least once.
+func ContainsAll(t TestingT, whole any, elements any, args ...any) bool {
+	countAssertion(t)
+	wholeElems, ok := elementsOf(t, whole, args)
+	if !ok {
+		return false
+	}
+	elems, ok := elementsOf(t, elements, args)
+	if !ok {
+		return false
+	}
+	var missing []any
+	for _, e := range elems {
+		if indexOfElement(wholeElems, e) < 0 {
+			missing = append(missing, e)
+		}
+	}
+	if len(missing) == 0 {
+		return true
+	}
+	msgArgs := []any{"Expected '%v' to contain all of '%v'", v(whole), v(elements), "Missing '%v'", v(missing)}
+	fail(
+		t,
+		true,
+		append(msgArgs, args...)...,
+	)
+	return false
+}
+
+// ContainsAny fails the test if the slice or array does not contain at least one of the elements.
+func ContainsAny(t TestingT, whole any, elements any, args ...any) bool {
+	countAssertion(t)
+	wholeElems, ok := elementsOf(t, whole, args)
+	if !ok {
+		return false
+	}
+	elems, ok := elementsOf(t, elements, args)
+	if !ok {
+		return false
+	}
+	for _, e := range elems {
+		if indexOfElement(wholeElems, e) >= 0 {
+			return true
+		}
+	}
+	msgArgs := []any{"Expected '%v' to contain any of '%v'", v(whole), v(elements)}
+	fail(
+		t,
+		true,
+		append(msgArgs, args...)...,
+	)
+	return false
+}
+
+// elementsOf returns the elements of a slice or array, failing the test if the object is neither.
+// Nil is treated as an empty slice.
+func elementsOf(t TestingT, obj any, args []any) (elems []any, ok bool) {
+	if isNil(obj) {
+		return nil, true
+	}
+	objValue := reflect.ValueOf(obj)
+	if objValue.Kind() != reflect.Slice && objValue.Kind() != reflect.Array {
+		msgArgs := []any{"Type %v is not a slice or array", objValue.Type()}
+		fail(
+			t,
+			true,
+			append(msgArgs, args...)...,
+		)
+		return nil, false
+	}
+	elems = make([]any, objValue.Len())
+	for i := range elems {
+		elems[i] = objValue.Index(i).Interface()
+	}
+	return elems, true
+}
+
+// indexOfElement returns the index of the first element deeply equal to the element, or -1 if not found.
+func indexOfElement(elems []any, element any) int {
+	for i := range elems {
+		if reflect.DeepEqual(elems[i], element) {
+			return i
+		}
+	}
+	return -1
+}
+
+// diffElements matches each of the expected elements to a distinct actual element.
+// It returns the expected elements that were not matched and the actual elements that were left over.
+func diffElements(expected []any, actual []any) (missing []any, extra []any) {
+	matched := make([]bool, len(actual))
+	for _, e := range expected {
+		found := false
+		for j := range actual {
+			if !matched[j] && reflect.DeepEqual(e, actual[j]) {
+				matched[j] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, e)
+		}
+	}
+	for j := range actual {
+		if !matched[j] {
+			extra = append(extra, actual[j])
+		}
+	}
+	return missing, extra
+}
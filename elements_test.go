@@ -0,0 +1,124 @@
+/*
+Copyright 2024-2025 Microbus LLC and various contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testarossa
+
+import (
+	"testing"
+)
+
+func Test_ElementsMatch(t *testing.T) {
+	mt := &MockTestingT{}
+
+	if !ElementsMatch(mt, []int{1, 2, 3}, []int{3, 1, 2}) || mt.Failed() {
+		t.FailNow()
+	}
+	if !ElementsMatch(mt, []int{1, 1, 2}, [3]int{1, 2, 1}) || mt.Failed() {
+		t.FailNow()
+	}
+	if ElementsMatch(mt, []int{1, 1, 2}, []int{1, 2, 2}) || mt.Passed() {
+		t.FailNow()
+	}
+	if ElementsMatch(mt, []int{1, 2}, []int{1, 2, 3}) || mt.Passed() {
+		t.FailNow()
+	}
+	if ElementsMatch(mt, []int{1, 2, 3}, []int{1, 2}) || mt.Passed() {
+		t.FailNow()
+	}
+	if !ElementsMatch(mt, nil, []int{}) || mt.Failed() {
+		t.FailNow()
+	}
+	if ElementsMatch(mt, []int{1}, []string{"1"}) || mt.Passed() {
+		t.FailNow()
+	}
+	if ElementsMatch(mt, 1, []int{1}) || mt.Passed() {
+		t.FailNow()
+	}
+
+	type E struct {
+		X int
+	}
+	if !ElementsMatch(mt, []E{{1}, {2}}, []E{{2}, {1}}) || mt.Failed() {
+		t.FailNow()
+	}
+	if !ElementsMatch(mt, [][]int{{1}, {2, 3}}, [][]int{{2, 3}, {1}}) || mt.Failed() {
+		t.FailNow()
+	}
+}
+
+func Test_Subset(t *testing.T) {
+	mt := &MockTestingT{}
+
+	if !Subset(mt, []int{1, 2, 3}, []int{3, 1}) || mt.Failed() {
+		t.FailNow()
+	}
+	if !Subset(mt, []int{1, 2, 3}, nil) || mt.Failed() {
+		t.FailNow()
+	}
+	if Subset(mt, []int{1, 2, 3}, []int{1, 4}) || mt.Passed() {
+		t.FailNow()
+	}
+	if Subset(mt, []int{1, 2, 3}, []int{1, 1}) || mt.Passed() {
+		t.FailNow()
+	}
+	if !Subset(mt, []int{1, 2, 1}, []int{1, 1}) || mt.Failed() {
+		t.FailNow()
+	}
+
+	if !NotSubset(mt, []int{1, 2, 3}, []int{1, 4}) || mt.Failed() {
+		t.FailNow()
+	}
+	if NotSubset(mt, []int{1, 2, 3}, []int{2}) || mt.Passed() {
+		t.FailNow()
+	}
+	if NotSubset(mt, "abc", []int{2}) || mt.Passed() {
+		t.FailNow()
+	}
+}
+
+func Test_ContainsAllAny(t *testing.T) {
+	mt := &MockTestingT{}
+
+	if !ContainsAll(mt, []string{"a", "b", "c"}, []string{"c", "a", "a"}) || mt.Failed() {
+		t.FailNow()
+	}
+	if ContainsAll(mt, []string{"a", "b", "c"}, []string{"a", "d"}) || mt.Passed() {
+		t.FailNow()
+	}
+	if !ContainsAll(mt, []string{"a"}, nil) || mt.Failed() {
+		t.FailNow()
+	}
+
+	if !ContainsAny(mt, []string{"a", "b", "c"}, []string{"x", "b"}) || mt.Failed() {
+		t.FailNow()
+	}
+	if ContainsAny(mt, []string{"a", "b", "c"}, []string{"x", "y"}) || mt.Passed() {
+		t.FailNow()
+	}
+	if ContainsAny(mt, []string{"a", "b", "c"}, nil) || mt.Passed() {
+		t.FailNow()
+	}
+}
+
+func Test_DiffElements(t *testing.T) {
+	missing, extra := diffElements([]any{1, 1, 2, 3}, []any{3, 1, 4, 4})
+	if !Equal(t, []any{1, 2}, missing) {
+		t.FailNow()
+	}
+	if !Equal(t, []any{4, 4}, extra) {
+		t.FailNow()
+	}
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2024-2025 Microbus LLC and various contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testarossa
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// looksAlike indicates if two different strings may look identical when printed.
+// This is the case when the first rune that differs between them is whitespace, invisible or a combining mark,
+// for example a trailing newline, a non-breaking space, a zero-width joiner or a combining accent,
+// when it is followed by a combining mark, as in different Unicode normalization forms,
+// or when the two runes are letters of different scripts that share homoglyphs, such as the Latin a and the Cyrillic а.
+// Visible differences, such as between two CJK characters, do not look alike.
+func looksAlike(s string, other string) bool {
+	if s == other {
+		return false
+	}
+	rs, ros := []rune(s), []rune(other)
+	offset := firstDifference(rs, ros)
+	r, ro := runeAt(rs, offset), runeAt(ros, offset)
+	if isInvisible(r) || isInvisible(ro) {
+		return true
+	}
+	if isCombining(runeAt(rs, offset+1)) || isCombining(runeAt(ros, offset+1)) {
+		return true
+	}
+	return homoglyphScript(r) != "" && homoglyphScript(ro) != "" && homoglyphScript(r) != homoglyphScript(ro)
+}
+
+// runeAt returns the rune at the offset, or -1 if the offset is beyond the end of the runes.
+func runeAt(rs []rune, offset int) rune {
+	if offset < 0 || offset >= len(rs) {
+		return -1
+	}
+	return rs[offset]
+}
+
+// isInvisible indicates if the rune is whitespace, a control or formatting character, or a combining mark.
+// The end of the string, represented by -1, is considered visible.
+func isInvisible(r rune) bool {
+	return r != -1 && (unicode.IsSpace(r) || !unicode.IsGraphic(r) || unicode.In(r, unicode.Cf) || isCombining(r))
+}
+
+// isCombining indicates if the rune is a combining mark.
+func isCombining(r rune) bool {
+	return r != -1 && unicode.In(r, unicode.Mn, unicode.Me)
+}
+
+// homoglyphScript returns the name of the script of the rune if it is Latin, Greek or Cyrillic,
+// whose letters are often indistinguishable from the letters of the other two.
+func homoglyphScript(r rune) string {
+	for _, script := range []string{"Latin", "Greek", "Cyrillic"} {
+		if unicode.Is(unicode.Scripts[script], r) {
+			return script
+		}
+	}
+	return ""
+}
+
+// confusable indicates if the first rune that differs between two strings is non-ASCII,
+// in which case all non-ASCII runes should be escaped, including printable ones.
+// This distinguishes homoglyphs such as the Latin a and the Cyrillic а, and different Unicode normalization forms.
+func confusable(s string, other string) bool {
+	r, ro := differingRunes(s, other)
+	return r >= utf8.RuneSelf || ro >= utf8.RuneSelf
+}
+
+// differingRunes returns the first rune of each string that differs from the rune at the same position in the other string.
+// A string that ends before the other is represented by -1.
+func differingRunes(s string, other string) (r rune, ro rune) {
+	rs, ros := []rune(s), []rune(other)
+	offset := firstDifference(rs, ros)
+	return runeAt(rs, offset), runeAt(ros, offset)
+}
+
+// escape returns the %q-style escaped form of s, without the surrounding quotes.
+// Newlines, tabs, backslashes, invisible runes and combining marks are escaped.
+// If ascii is true, all non-ASCII runes are escaped, including printable ones.
+func escape(s string, ascii bool) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			fmt.Fprintf(&sb, `\x%02x`, s[i])
+			i++
+			continue
+		}
+		i += size
+		switch {
+		case r == '\\':
+			sb.WriteString(`\\`)
+		case r == '\n':
+			sb.WriteString(`\n`)
+		case r == '\r':
+			sb.WriteString(`\r`)
+		case r == '\t':
+			sb.WriteString(`\t`)
+		case r >= ' ' && r < 0x7f:
+			sb.WriteRune(r)
+		case r < ' ' || r == 0x7f:
+			fmt.Fprintf(&sb, `\x%02x`, r)
+		case !ascii && unicode.IsPrint(r) && !unicode.In(r, unicode.Mn, unicode.Me):
+			sb.WriteRune(r)
+		case r > 0xffff:
+			fmt.Fprintf(&sb, `\U%08x`, r)
+		default:
+			fmt.Fprintf(&sb, `\u%04x`, r)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2024-2025 Microbus LLC and various contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testarossa
+
+import (
+	"testing"
+)
+
+func Test_Escape(t *testing.T) {
+	testCases := []struct {
+		s        string
+		ascii    bool
+		expected string
+	}{
+		{"hello", false, `hello`},
+		{"a\nb\tc\r", false, `a\nb\tc\r`},
+		{`back\slash "quoted" 'single'`, false, `back\\slash "quoted" 'single'`},
+		{"nb\u00a0sp", false, `nb\u00a0sp`},
+		{"zero\u200dwidth", false, `zero\u200dwidth`},
+		{"caf\u00e9", false, "caf\u00e9"},
+		{"cafe\u0301", false, `cafe\u0301`},
+		{"caf\u00e9", true, `caf\u00e9`},
+		{"\x00\x7f", false, `\x00\x7f`},
+		{"invalid\xff", false, `invalid\xff`},
+		{"emoji\U0001f600", false, "emoji\U0001f600"},
+		{"emoji\U0001f600", true, `emoji\U0001f600`},
+	}
+	for _, tc := range testCases {
+		if escape(tc.s, tc.ascii) != tc.expected {
+			t.Errorf("%q: expected %s, actual %s", tc.s, tc.expected, escape(tc.s, tc.ascii))
+		}
+	}
+}
+
+func Test_LooksAlike(t *testing.T) {
+	if looksAlike("x", "x") || looksAlike("abc", "abd") || looksAlike("abc", "abcd") {
+		t.FailNow()
+	}
+	if !looksAlike("x\n", "x") || !looksAlike("a\tb", "a b") || !looksAlike("a\u00a0b", "a b") {
+		t.FailNow()
+	}
+	if !looksAlike("a\u200db", "ab") || !looksAlike("caf\u00e9", "cafe\u0301") || !looksAlike("p\u0430ypal", "paypal") {
+		t.FailNow()
+	}
+	if looksAlike("\u65e5\u672c", "\u65e5\u6587") || looksAlike("\u00e9t\u00e9", "\u00e9t\u00e8") || looksAlike("a\u0430", "a\u3042") {
+		t.FailNow()
+	}
+	if confusable("x\n", "x") || !confusable("caf\u00e9", "cafe\u0301") || !confusable("a\u00a0b", "a b") {
+		t.FailNow()
+	}
+}
+
+func Test_EscapeInMessages(t *testing.T) {
+	rt := &RecordingTestingT{}
+
+	testCases := []struct {
+		expected any
+		actual   any
+		message  string
+	}{
+		{"x\n", "x", `Expected 'x\n', actual 'x'`},
+		{"a\tb", "a b", `Expected 'a\tb', actual 'a b'`},
+		{"a\u00a0b", "a b", `Expected 'a\u00a0b', actual 'a b'`},
+		{"a\u200db", "ab", `Expected 'a\u200db', actual 'ab'`},
+		{"caf\u00e9", "cafe\u0301", `Expected 'caf\u00e9', actual 'cafe\u0301'`},
+		{"p\u0430ypal", "paypal", `Expected 'p\u0430ypal', actual 'paypal'`},
+		{"abc", "abd", `Expected 'abc', actual 'abd'`},
+		{"a\nc", "a\nd", "Expected 'a\nc', actual 'a\nd'"},
+		{"\u65e5\u672c", "\u65e5\u6587", "Expected '\u65e5\u672c', actual '\u65e5\u6587'"},
+	}
+	for _, tc := range testCases {
+		if Equal(rt, tc.expected, tc.actual) || rt.Passed() {
+			t.FailNow()
+		}
+		if rt.message != tc.message {
+			t.Errorf("expected %s, actual %s", tc.message, rt.message)
+		}
+	}
+
+	// Generic and map assertions
+	if EqualT(rt, "abc\n", "abc") || rt.Passed() {
+		t.FailNow()
+	}
+	if rt.message != `Expected 'abc\n', actual 'abc'` {
+		t.FailNow()
+	}
+	if ContainsEntry(rt, map[string]string{"k": "abc"}, "k", "abc\n") || rt.Passed() {
+		t.FailNow()
+	}
+	if rt.message != `Expected 'abc\n' at key 'k', actual 'abc'` {
+		t.FailNow()
+	}
+
+	// Always
+	if Equal(rt, "a\nc", "a\nd", EscapeAlways()) || rt.Passed() {
+		t.FailNow()
+	}
+	if rt.message != `Expected 'a\nc', actual 'a\nd'` {
+		t.FailNow()
+	}
+	if For(rt).WithOptions(EscapeAlways()).Contains("a\nb", "c") || rt.Passed() {
+		t.FailNow()
+	}
+	if rt.message != `Expected 'a\nb' to contain 'c'` {
+		t.FailNow()
+	}
+
+	// Custom assertions
+	expectedStr, actualStr := FormatExpectedActual("x\n", "x")
+	if expectedStr != `x\n` || actualStr != `x` {
+		t.FailNow()
+	}
+}
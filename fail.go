@@ -19,6 +19,8 @@ package testarossa
 import (
 	"fmt"
 	"runtime"
+	"slices"
+	"strconv"
 	"strings"
 )
 
@@ -32,47 +34,265 @@ type TestingT interface {
 // FailIf fails the test if the condition is met.
 // If returns back the result of evaluating the condition.
 func FailIf(t TestingT, condition bool, args ...any) bool {
+	if h, ok := underlyingT(t).(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	countAssertion(t)
+	return failIf(t, condition, nil, args)
+}
+
+// fail is the FailIf of the assertions of testarossa, which count themselves.
+func fail(t TestingT, condition bool, args ...any) bool {
+	if h, ok := underlyingT(t).(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	return failIf(t, condition, nil, args)
+}
+
+// failIf implements FailIf.
+// The frames of the helper functions are omitted from the stack trace.
+// If the test supports cleanup functions, the failure is counted towards the summary printed at the end of the test.
+func failIf(t TestingT, condition bool, helpers []string, args []any) bool {
 	if !condition {
 		return false
 	}
+	if h, ok := underlyingT(t).(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	frames := append(casePositions(t), stackFrames(helpers)...)
+	failAt(t, frames, args)
+	return true
+}
+
+// failAt fails the test and prints the frames that lead to the failure, followed by the formatted args.
+// The failure hooks run after the failure is printed, and before the test is failed.
+// It is used directly by checks that run at the end of the test, when the stack no longer leads to the test function.
+func failAt(t TestingT, frames []frame, args []any) {
+	countFailure(t)
+	message := formatMessage(args, optionsOf(t, args))
+	labels := labelsOfT(t)
+	if recorder, ok := underlyingT(t).(failureRecorder); ok {
+		var file string
+		var line int
+		if len(frames) > 0 {
+			file, line = frames[len(frames)-1].file, frames[len(frames)-1].line
+		}
+		recorder.RecordFailure(file, line, message, labels)
+	} else {
+		printFailure(t, frames, message, labels, len(args) == 0)
+	}
+	runFailureHooks(t, frames, message, labels, args)
+	t.Fail()
+}
+
+// printFailure prints the frames that lead to the failure, followed by its labels and message.
+func printFailure(t TestingT, frames []frame, message string, labels []Label, noArgs bool) {
 	var sb strings.Builder
+	for _, f := range frames {
+		sb.WriteString(fmt.Sprintf("    %s:%d\n", f.file, f.line))
+	}
+	if len(labels) > 0 {
+		sb.WriteString("    ")
+		sb.WriteString(formatLabels(labels))
+		sb.WriteString("\n")
+	}
+	if message != "" {
+		sb.WriteString("    ")
+		sb.WriteString(strings.ReplaceAll(message, "\n", "\n    "))
+		sb.WriteString("\n")
+	} else if noArgs {
+		sb.WriteString("\n")
+	}
+	printf("--- FAIL: %s\n%s", t.Name(), sb.String())
+}
+
+// failureRecorder is implemented by test doubles, such as testarossatest.TestingT,
+// that capture failures rather than have them printed.
+// The labels are those of the Asserter that made the failed assertion, if any.
+type failureRecorder interface {
+	RecordFailure(file string, line int, message string, labels []Label)
+}
+
+// underlyingT returns the TestingT that an Asserter wraps.
+func underlyingT(t TestingT) TestingT {
+	for {
+		tt, ok := t.(*Asserter)
+		if !ok {
+			return t
+		}
+		t = tt.t
+	}
+}
+
+// casePositions returns the positions of the table test cases that the Asserter is running, from the outermost to the innermost.
+func casePositions(t TestingT) (frames []frame) {
+	for {
+		tt, ok := t.(*Asserter)
+		if !ok {
+			return frames
+		}
+		if tt.casePos != nil {
+			frames = append([]frame{*tt.casePos}, frames...)
+		}
+		t = tt.t
+	}
+}
+
+// formatMessage formats the args of FailIf into a multi-line message.
+// A string arg is a format string for the args that follow it, as many as its verbs consume.
+// A string that is not a well-formed format string, or whose verbs consume more args than follow it, is taken literally.
+// Options are skipped, and rendered values are truncated as per the options.
+func formatMessage(args []any, opts options) string {
+	var lines []string
 	i := 0
 	for i < len(args) {
 		val := ""
-		if str, ok := args[i].(string); ok {
-			pctCount := strings.Count(str, "%") - 2*strings.Count(str, "%%")
-			pctCount = min(pctCount, len(args)-i-1)
-			val = fmt.Sprintf(str, args[i+1:i+1+pctCount]...)
-			i += pctCount + 1
-		} else {
-			val = fmt.Sprintf("%+v", args[i])
+		switch arg := args[i].(type) {
+		case Option:
+			i++
+		case Message:
+			val = arg.String()
+			i++
+		case value, diffValue, diffOffset:
+			val = fmt.Sprint(opts.render(arg))
+			i++
+		case string:
+			argCount, ok := formatArgCount(arg)
+			if !ok || argCount > len(args)-i-1 {
+				val = arg
+				i++
+			} else {
+				formatArgs := make([]any, argCount)
+				for j := range formatArgs {
+					formatArgs[j] = opts.render(args[i+1+j])
+				}
+				val = fmt.Sprintf(arg, formatArgs...)
+				i += argCount + 1
+			}
+		default:
+			val = fmt.Sprintf("%+v", arg)
 			i++
 		}
 		if val == "" {
 			continue
 		}
-		val = strings.ReplaceAll(val, "\n", "\n    ")
-		sb.WriteString("    ")
-		sb.WriteString(val)
-		sb.WriteString("\n")
+		lines = append(lines, val)
 	}
-	if len(args) == 0 {
-		sb.WriteString("\n")
+	return strings.Join(lines, "\n")
+}
+
+// formatArgCount returns the number of args consumed by the verbs of a format string.
+// Flags, width, precision, * and explicit arg indexes such as %[2]d are taken into account.
+// It returns false if the format string is malformed, for example if it ends with a lone %.
+func formatArgCount(format string) (argCount int, ok bool) {
+	argNum := 0
+	consume := func() {
+		argNum++
+		argCount = max(argCount, argNum)
 	}
-	fmt.Printf("--- FAIL: %s\n%s%s", t.Name(), stackTrace(), sb.String())
-	t.Fail()
-	return true
+	i := 0
+	for i < len(format) {
+		if format[i] != '%' {
+			i++
+			continue
+		}
+		i++
+		if i < len(format) && format[i] == '%' {
+			i++
+			continue
+		}
+		for i < len(format) && strings.IndexByte("+-# 0", format[i]) >= 0 {
+			i++
+		}
+		i, argNum, ok = formatArgIndex(format, i, argNum)
+		if !ok {
+			return argCount, false
+		}
+		if i < len(format) && format[i] == '*' {
+			consume()
+			i++
+		} else {
+			for i < len(format) && format[i] >= '0' && format[i] <= '9' {
+				i++
+			}
+		}
+		if i < len(format) && format[i] == '.' {
+			i++
+			i, argNum, ok = formatArgIndex(format, i, argNum)
+			if !ok {
+				return argCount, false
+			}
+			if i < len(format) && format[i] == '*' {
+				consume()
+				i++
+			} else {
+				for i < len(format) && format[i] >= '0' && format[i] <= '9' {
+					i++
+				}
+			}
+		}
+		i, argNum, ok = formatArgIndex(format, i, argNum)
+		if !ok || i >= len(format) || strings.IndexByte("vTtbcdoOqxXUeEfFgGspw", format[i]) < 0 {
+			return argCount, false
+		}
+		consume()
+		i++
+	}
+	return argCount, true
+}
+
+// formatArgIndex parses an explicit arg index such as [2] at position i of the format string.
+// It returns the position following the index and the 0-based number of the next arg to consume.
+func formatArgIndex(format string, i int, argNum int) (int, int, bool) {
+	if i >= len(format) || format[i] != '[' {
+		return i, argNum, true
+	}
+	end := strings.IndexByte(format[i:], ']')
+	if end < 0 {
+		return i, argNum, false
+	}
+	n, err := strconv.Atoi(format[i+1 : i+end])
+	if err != nil || n < 1 {
+		return i, argNum, false
+	}
+	return i + end + 1, n - 1, true
+}
+
+/*
+Message is a preformatted message that can be passed to FailIf and to the args of any assertion.
+Unlike a plain string, it never consumes the args that follow it, and a % in its args is never interpreted.
+
+	testarossa.Equal(t, expected, actual, testarossa.Msg("CPU at %d%%", cpu), details)
+*/
+type Message struct {
+	Format string
+	Args   []any
+}
+
+// String formats the message.
+func (m Message) String() string {
+	return fmt.Sprintf(m.Format, m.Args...)
+}
+
+// Msg creates a preformatted message that can be passed to FailIf and to the args of any assertion.
+func Msg(format string, args ...any) Message {
+	return Message{Format: format, Args: args}
 }
 
 // FailIfError is a shortcut to FailIf(t, err != nil, append([]any{err}, args...)...) .
 func FailIfError(t TestingT, err error, args ...any) bool {
-	return FailIf(t, err != nil, append([]any{err}, args...)...)
+	countAssertion(t)
+	if err == nil {
+		return false
+	}
+	return fail(t, true, append([]any{err}, args...)...)
 }
 
 // FatalIf fails the test and stops further execution if the condition is met.
 func FatalIf(t TestingT, condition bool, args ...any) bool {
+	countAssertion(t)
 	if condition {
-		FailIf(t, condition, args...)
+		fail(t, condition, args...)
 		t.FailNow()
 	}
 	return condition
@@ -80,30 +300,52 @@ func FatalIf(t TestingT, condition bool, args ...any) bool {
 
 // FatalIfError is a shortcut to FatalIf(t, err != nil, append([]any{err}, args...)...) .
 func FatalIfError(t TestingT, err error, args ...any) bool {
-	return FatalIf(t, err != nil, append([]any{err}, args...)...)
+	countAssertion(t)
+	if err == nil {
+		return false
+	}
+	fail(t, true, append([]any{err}, args...)...)
+	t.FailNow()
+	return true
 }
 
-func stackTrace() (stackTrace string) {
+// frame is a location in the source code.
+type frame struct {
+	file string
+	line int
+}
+
+// stackFrames returns the frames of the call stack that lead to the failed assertion, from the outermost to the innermost.
+// Frames of testarossa itself, of its testarossatest package and of the helper functions are skipped, as are frames beyond the test function.
+func stackFrames(helpers []string) (frames []frame) {
 	for lvl := 2; true; lvl++ {
 		pc, file, line, ok := runtime.Caller(lvl)
 		if !ok {
 			break
 		}
 		funcName := runtime.FuncForPC(pc).Name()
+		if slices.Contains(helpers, funcName) {
+			continue
+		}
 		p := strings.LastIndex(funcName, "/")
 		if p > 0 {
 			funcName = funcName[p+1:]
 		}
-		if strings.HasPrefix(funcName, "testarossa.") && !strings.HasPrefix(funcName, "testarossa.Test") {
+		if pkg, name, _ := strings.Cut(funcName, "."); (pkg == "testarossa" || pkg == "testarossatest") && !strings.HasPrefix(name, "Test") {
 			continue
 		}
 		if funcName == "testing.tRunner" {
 			break
 		}
-		stackTrace = fmt.Sprintf("    %s:%d\n%s", file, line, stackTrace)
-		if strings.Contains(funcName, ".Test") || strings.Contains(funcName, ".Benchmark") {
+		frames = append([]frame{{file: file, line: line}}, frames...)
+		if isTestFunc(funcName) {
 			break
 		}
 	}
-	return stackTrace
+	return frames
+}
+
+// isTestFunc indicates if the function name is that of a test or a benchmark, or of a closure within one.
+func isTestFunc(funcName string) bool {
+	return strings.Contains(funcName, ".Test") || strings.Contains(funcName, ".Benchmark")
 }
@@ -73,3 +73,39 @@ func Test_FailIfError(t *testing.T) {
 		}
 	})
 }
+
+func Test_FormatMessage(t *testing.T) {
+	testCases := []struct {
+		args     []any
+		expected string
+	}{
+		{[]any{"Hello"}, "Hello"},
+		{[]any{"Hello %s", "World"}, "Hello World"},
+		{[]any{"Hello %s", "World", "Bye"}, "Hello World\nBye"},
+		{[]any{"100%% sure", "Bye"}, "100% sure\nBye"},
+		{[]any{"CPU at 95%", "Bye"}, "CPU at 95%\nBye"},
+		{[]any{"CPU at 95%", 5}, "CPU at 95%\n5"},
+		{[]any{"%d%% of %s", 5, "x", "Bye"}, "5% of x\nBye"},
+		{[]any{"%+-5d|", 5, "Bye"}, "+5   |\nBye"},
+		{[]any{"%*d|", 3, 5, "Bye"}, "  5|\nBye"},
+		{[]any{"%.*f|", 1, 1.25, "Bye"}, "1.2|\nBye"},
+		{[]any{"%6.2f|", 1.0, "Bye"}, "  1.00|\nBye"},
+		{[]any{"%[2]d %[1]d", 1, 2, "Bye"}, "2 1\nBye"},
+		{[]any{"%[2]d %d", 1, 2, 3, "Bye"}, "2 3\nBye"},
+		{[]any{"%[1]d %[1]d", 1, "Bye"}, "1 1\nBye"},
+		{[]any{"%[x]d", 1}, "%[x]d\n1"},
+		{[]any{"Expected %d, actual %d", 1}, "Expected %d, actual %d\n1"},
+		{[]any{"Value", "50%"}, "Value\n50%"},
+		{[]any{"Value %s", "50%", "Bye"}, "Value 50%\nBye"},
+		{[]any{Msg("CPU at %d%%", 95), "Bye"}, "CPU at 95%\nBye"},
+		{[]any{Msg("Value %s", "x"), "%d", 5}, "Value x\n5"},
+		{[]any{Msg("50%")}, "50%!(NOVERB)"},
+		{[]any{errors.New("bad %d"), 5}, "bad %d\n5"},
+		{[]any{"", "Bye"}, "Bye"},
+	}
+	for _, tc := range testCases {
+		if formatMessage(tc.args, options{maxLen: defaultMaxLen}) != tc.expected {
+			t.Errorf("%v: expected %q, actual %q", tc.args, tc.expected, formatMessage(tc.args, options{maxLen: defaultMaxLen}))
+		}
+	}
+}
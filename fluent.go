@@ -0,0 +1,280 @@
+/*
+Copyright 2024-2025 Microbus LLC and various contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testarossa
+
+/*
+Subject is the target of a fluent chain of assertions.
+Each link of the chain is a functional assertion, so failure messages are identical to those of the functional pattern.
+The chain stops at the first failed link.
+
+	tt.That(order.Total).IsGreaterThan(0).IsLessThan(1000)
+	tt.That(err).IsNil()
+*/
+type Subject struct {
+	tt     *Asserter
+	actual any
+	failed bool
+}
+
+// That starts a fluent chain of assertions on the actual value.
+func (tt *Asserter) That(actual any) *Subject {
+	return &Subject{tt: tt, actual: actual}
+}
+
+// Passed indicates if all links of the chain passed.
+func (s *Subject) Passed() bool {
+	return !s.failed
+}
+
+// Equals fails the test if the actual value is not equal to the expected value.
+func (s *Subject) Equals(expected any, args ...any) *Subject {
+	if !s.failed {
+		s.failed = !Equal(s.tt, expected, s.actual, args...)
+	}
+	return s
+}
+
+// NotEquals fails the test if the actual value is equal to the unexpected value.
+func (s *Subject) NotEquals(unexpected any, args ...any) *Subject {
+	if !s.failed {
+		s.failed = !NotEqual(s.tt, unexpected, s.actual, args...)
+	}
+	return s
+}
+
+// IsNil fails the test if the actual value is not nil.
+func (s *Subject) IsNil(args ...any) *Subject {
+	if !s.failed {
+		s.failed = !Nil(s.tt, s.actual, args...)
+	}
+	return s
+}
+
+// IsNotNil fails the test if the actual value is nil.
+func (s *Subject) IsNotNil(args ...any) *Subject {
+	if !s.failed {
+		s.failed = !NotNil(s.tt, s.actual, args...)
+	}
+	return s
+}
+
+// IsZero fails the test if the actual value is not the 0 value of its type.
+func (s *Subject) IsZero(args ...any) *Subject {
+	if !s.failed {
+		s.failed = !Zero(s.tt, s.actual, args...)
+	}
+	return s
+}
+
+// IsNotZero fails the test if the actual value is the 0 value of its type.
+func (s *Subject) IsNotZero(args ...any) *Subject {
+	if !s.failed {
+		s.failed = !NotZero(s.tt, s.actual, args...)
+	}
+	return s
+}
+
+// IsTrue fails the test if the actual value is not true.
+func (s *Subject) IsTrue(args ...any) *Subject {
+	if !s.failed {
+		if b, ok := s.actual.(bool); ok {
+			s.failed = !True(s.tt, b, args...)
+		} else {
+			s.failed = !Equal(s.tt, true, s.actual, args...)
+		}
+	}
+	return s
+}
+
+// IsFalse fails the test if the actual value is not false.
+func (s *Subject) IsFalse(args ...any) *Subject {
+	if !s.failed {
+		if b, ok := s.actual.(bool); ok {
+			s.failed = !False(s.tt, b, args...)
+		} else {
+			s.failed = !Equal(s.tt, false, s.actual, args...)
+		}
+	}
+	return s
+}
+
+// IsGreaterThan fails the test if the actual value is not greater than the threshold.
+func (s *Subject) IsGreaterThan(threshold any, args ...any) *Subject {
+	if !s.failed {
+		s.failed = !greater(s.tt, s.actual, threshold, args...)
+	}
+	return s
+}
+
+// IsGreaterOrEqual fails the test if the actual value is less than the threshold.
+func (s *Subject) IsGreaterOrEqual(threshold any, args ...any) *Subject {
+	if !s.failed {
+		s.failed = !greaterOrEqual(s.tt, s.actual, threshold, args...)
+	}
+	return s
+}
+
+// IsLessThan fails the test if the actual value is not less than the threshold.
+func (s *Subject) IsLessThan(threshold any, args ...any) *Subject {
+	if !s.failed {
+		s.failed = !less(s.tt, s.actual, threshold, args...)
+	}
+	return s
+}
+
+// IsLessOrEqual fails the test if the actual value is greater than the threshold.
+func (s *Subject) IsLessOrEqual(threshold any, args ...any) *Subject {
+	if !s.failed {
+		s.failed = !lessOrEqual(s.tt, s.actual, threshold, args...)
+	}
+	return s
+}
+
+// Contains fails the test if the actual value does not contain the sub.
+func (s *Subject) Contains(sub any, args ...any) *Subject {
+	if !s.failed {
+		s.failed = !Contains(s.tt, s.actual, sub, args...)
+	}
+	return s
+}
+
+// NotContains fails the test if the actual value contains the sub.
+func (s *Subject) NotContains(sub any, args ...any) *Subject {
+	if !s.failed {
+		s.failed = !NotContains(s.tt, s.actual, sub, args...)
+	}
+	return s
+}
+
+// HasLen fails the test if the length of the actual value does not match the expected len.
+func (s *Subject) HasLen(length int, args ...any) *Subject {
+	if !s.failed {
+		s.failed = !Len(s.tt, s.actual, length, args...)
+	}
+	return s
+}
+
+/*
+StringSubject is the target of a fluent chain of assertions on a string.
+Each link of the chain is a functional assertion, so failure messages are identical to those of the functional pattern.
+The chain stops at the first failed link.
+
+	tt.ThatString(s).StartsWith("x").Matches(`^x[0-9]+$`)
+*/
+type StringSubject struct {
+	tt     *Asserter
+	actual string
+	failed bool
+}
+
+// ThatString starts a fluent chain of assertions on the actual string.
+func (tt *Asserter) ThatString(actual string) *StringSubject {
+	return &StringSubject{tt: tt, actual: actual}
+}
+
+// Passed indicates if all links of the chain passed.
+func (s *StringSubject) Passed() bool {
+	return !s.failed
+}
+
+// Equals fails the test if the actual string is not equal to the expected string.
+func (s *StringSubject) Equals(expected string, args ...any) *StringSubject {
+	if !s.failed {
+		s.failed = !Equal(s.tt, expected, s.actual, args...)
+	}
+	return s
+}
+
+// NotEquals fails the test if the actual string is equal to the unexpected string.
+func (s *StringSubject) NotEquals(unexpected string, args ...any) *StringSubject {
+	if !s.failed {
+		s.failed = !NotEqual(s.tt, unexpected, s.actual, args...)
+	}
+	return s
+}
+
+// IsEmpty fails the test if the actual string is not empty.
+func (s *StringSubject) IsEmpty(args ...any) *StringSubject {
+	if !s.failed {
+		s.failed = !Zero(s.tt, s.actual, args...)
+	}
+	return s
+}
+
+// IsNotEmpty fails the test if the actual string is empty.
+func (s *StringSubject) IsNotEmpty(args ...any) *StringSubject {
+	if !s.failed {
+		s.failed = !NotZero(s.tt, s.actual, args...)
+	}
+	return s
+}
+
+// StartsWith fails the test if the actual string doesn't start with the prefix.
+func (s *StringSubject) StartsWith(prefix string, args ...any) *StringSubject {
+	if !s.failed {
+		s.failed = !hasPrefix(s.tt, s.actual, prefix, args...)
+	}
+	return s
+}
+
+// EndsWith fails the test if the actual string doesn't end with the suffix.
+func (s *StringSubject) EndsWith(suffix string, args ...any) *StringSubject {
+	if !s.failed {
+		s.failed = !hasSuffix(s.tt, s.actual, suffix, args...)
+	}
+	return s
+}
+
+// Contains fails the test if the actual string does not contain the substring.
+func (s *StringSubject) Contains(substr string, args ...any) *StringSubject {
+	if !s.failed {
+		s.failed = !Contains(s.tt, s.actual, substr, args...)
+	}
+	return s
+}
+
+// NotContains fails the test if the actual string contains the substring.
+func (s *StringSubject) NotContains(substr string, args ...any) *StringSubject {
+	if !s.failed {
+		s.failed = !NotContains(s.tt, s.actual, substr, args...)
+	}
+	return s
+}
+
+// Matches fails the test if the actual string doesn't match the regular expression.
+func (s *StringSubject) Matches(regexpStr string, args ...any) *StringSubject {
+	if !s.failed {
+		s.failed = !Match(s.tt, s.actual, regexpStr, args...)
+	}
+	return s
+}
+
+// NotMatches fails the test if the actual string matches the regular expression.
+func (s *StringSubject) NotMatches(regexpStr string, args ...any) *StringSubject {
+	if !s.failed {
+		s.failed = !NotMatch(s.tt, s.actual, regexpStr, args...)
+	}
+	return s
+}
+
+// HasLen fails the test if the length of the actual string does not match the expected len.
+func (s *StringSubject) HasLen(length int, args ...any) *StringSubject {
+	if !s.failed {
+		s.failed = !Len(s.tt, s.actual, length, args...)
+	}
+	return s
+}
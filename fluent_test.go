@@ -0,0 +1,112 @@
+/*
+Copyright 2024-2025 Microbus LLC and various contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testarossa
+
+import (
+	"errors"
+	"runtime"
+	"testing"
+)
+
+func TestFluent_That(t *testing.T) {
+	mt := &MockTestingT{}
+	tt := For(mt)
+
+	if !tt.That(500).IsGreaterThan(0).IsLessThan(1000).IsGreaterOrEqual(500).IsLessOrEqual(500).Passed() || mt.Failed() {
+		t.FailNow()
+	}
+	if !tt.That(12.5).IsGreaterThan(0).IsLessThan(1000).Passed() || mt.Failed() {
+		t.FailNow()
+	}
+	if !tt.That(1).Equals(1).NotEquals(2).IsNotZero().IsNotNil().Passed() || mt.Failed() {
+		t.FailNow()
+	}
+	if !tt.That(nil).IsNil().IsZero().Passed() || mt.Failed() {
+		t.FailNow()
+	}
+	if !tt.That(true).IsTrue().Passed() || mt.Failed() {
+		t.FailNow()
+	}
+	if !tt.That(false).IsFalse().Passed() || mt.Failed() {
+		t.FailNow()
+	}
+	if tt.That("true").IsTrue().Passed() || mt.Passed() {
+		t.FailNow()
+	}
+	if !tt.That([]int{1, 2, 3}).HasLen(3).Contains(2).NotContains(4).Passed() || mt.Failed() {
+		t.FailNow()
+	}
+
+	var err error
+	if !tt.That(err).IsNil().Passed() || mt.Failed() {
+		t.FailNow()
+	}
+	err = errors.New("bad")
+	if tt.That(err).IsNil().Passed() || mt.Passed() {
+		t.FailNow()
+	}
+}
+
+func TestFluent_StopsAtFirstFailure(t *testing.T) {
+	rt := &RecordingTestingT{}
+	tt := For(rt)
+
+	_, _, line, _ := runtime.Caller(0)
+	s := tt.That(2000).
+		IsGreaterThan(0).
+		IsLessThan(1000).
+		IsLessThan(10)
+	if s.Passed() || rt.Passed() {
+		t.FailNow()
+	}
+	// The failure points at the line of the failed link, with the message of the functional assertion
+	if rt.line != line+3 {
+		t.FailNow()
+	}
+	if rt.message != "Expected '2000' to be less than '1000'" {
+		t.FailNow()
+	}
+	// Links after the first failure are not evaluated
+	if s.IsNil().Passed() || rt.Failed() {
+		t.FailNow()
+	}
+}
+
+func TestFluent_ThatString(t *testing.T) {
+	mt := &MockTestingT{}
+	tt := For(mt)
+
+	s := "x123"
+	if !tt.ThatString(s).StartsWith("x").EndsWith("3").Contains("12").NotContains("y").Matches(`^x[0-9]+$`).NotMatches(`y`).Passed() || mt.Failed() {
+		t.FailNow()
+	}
+	if !tt.ThatString(s).Equals("x123").NotEquals("x").HasLen(4).IsNotEmpty().Passed() || mt.Failed() {
+		t.FailNow()
+	}
+	if !tt.ThatString("").IsEmpty().Passed() || mt.Failed() {
+		t.FailNow()
+	}
+	if tt.ThatString(s).StartsWith("y").Passed() || mt.Passed() {
+		t.FailNow()
+	}
+	if tt.ThatString(s).EndsWith("y").Passed() || mt.Passed() {
+		t.FailNow()
+	}
+	if tt.ThatString(s).Matches(`^[0-9]+$`).Passed() || mt.Passed() {
+		t.FailNow()
+	}
+}
@@ -0,0 +1,206 @@
+/*
+Copyright 2024-2025 Microbus LLC and various contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testarossa
+
+import (
+	"reflect"
+	"slices"
+)
+
+// EqualT fails the test if the two values are not equal.
+// Unlike Equal, values of mismatched types do not compile.
+// Interface values that hold values of an uncomparable type, such as slices, are compared with reflect.DeepEqual.
+// Note: the expected value comes before the actual value in the argument list.
+func EqualT[T comparable](t TestingT, expected T, actual T, args ...any) bool {
+	countAssertion(t)
+	if equalT(expected, actual) {
+		return true
+	}
+	msgArgs := expectedActualArgs(expected, actual)
+	fail(
+		t,
+		true,
+		append(msgArgs, args...)...,
+	)
+	return false
+}
+
+// NotEqualT fails the test if the two values are equal.
+// Unlike NotEqual, values of mismatched types do not compile.
+// Note: the expected value comes before the actual value in the argument list.
+func NotEqualT[T comparable](t TestingT, unexpected T, actual T, args ...any) bool {
+	countAssertion(t)
+	if !equalT(unexpected, actual) {
+		return true
+	}
+	msgArgs := []any{"Unexpected to equal '%v'", v(unexpected)}
+	fail(
+		t,
+		true,
+		append(msgArgs, args...)...,
+	)
+	return false
+}
+
+// ContainsT fails the test if the slice does not contain the element.
+// Unlike Contains, an element of the wrong type does not compile.
+func ContainsT[S ~[]E, E comparable](t TestingT, slice S, element E, args ...any) bool {
+	countAssertion(t)
+	if containsT(slice, element) {
+		return true
+	}
+	msgArgs := []any{"Expected '%v' to contain '%v'", v(slice), v(element)}
+	fail(
+		t,
+		true,
+		append(msgArgs, args...)...,
+	)
+	return false
+}
+
+// NotContainsT fails the test if the slice contains the element.
+// Unlike NotContains, an element of the wrong type does not compile.
+func NotContainsT[S ~[]E, E comparable](t TestingT, slice S, element E, args ...any) bool {
+	countAssertion(t)
+	if !containsT(slice, element) {
+		return true
+	}
+	msgArgs := []any{"Expected '%v' not to contain '%v'", v(slice), v(element)}
+	fail(
+		t,
+		true,
+		append(msgArgs, args...)...,
+	)
+	return false
+}
+
+// KeyT fails the test if the map does not contain the key.
+// Unlike Contains, a key of the wrong type does not compile.
+func KeyT[M ~map[K]V, K comparable, V any](t TestingT, m M, key K, args ...any) bool {
+	countAssertion(t)
+	if hasKeyT(m, key) {
+		return true
+	}
+	msgArgs := []any{"Expected '%v' to contain '%v'", v(m), v(key)}
+	fail(
+		t,
+		true,
+		append(msgArgs, args...)...,
+	)
+	return false
+}
+
+// NotKeyT fails the test if the map contains the key.
+// Unlike NotContains, a key of the wrong type does not compile.
+func NotKeyT[M ~map[K]V, K comparable, V any](t TestingT, m M, key K, args ...any) bool {
+	countAssertion(t)
+	if !hasKeyT(m, key) {
+		return true
+	}
+	msgArgs := []any{"Expected '%v' not to contain '%v'", v(m), v(key)}
+	fail(
+		t,
+		true,
+		append(msgArgs, args...)...,
+	)
+	return false
+}
+
+// LenT fails the test if the length of the slice does not match the expected len.
+func LenT[S ~[]E, E any](t TestingT, slice S, length int, args ...any) bool {
+	countAssertion(t)
+	if len(slice) == length {
+		return true
+	}
+	msgArgs := []any{"Expected length %d, actual %d", length, len(slice)}
+	fail(
+		t,
+		true,
+		append(msgArgs, args...)...,
+	)
+	return false
+}
+
+// TypedAsserter pairs an Asserter with the generic type-safe assertions for values of type T.
+// Go does not permit type parameters on methods, so the typed assertions are obtained via [Typed].
+//
+//	tt := testarossa.For(t)
+//	testarossa.Typed[int](tt).Equal(1, count)
+type TypedAsserter[T comparable] struct {
+	tt *Asserter
+}
+
+// Typed returns a TypedAsserter for values of type T that reports failures through the Asserter.
+func Typed[T comparable](tt *Asserter) *TypedAsserter[T] {
+	return &TypedAsserter[T]{tt: tt}
+}
+
+// Equal fails the test if the two values are not equal.
+// Note: the expected value comes before the actual value in the argument list.
+func (ta *TypedAsserter[T]) Equal(expected T, actual T, args ...any) bool {
+	return EqualT(ta.tt, expected, actual, args...)
+}
+
+// NotEqual fails the test if the two values are equal.
+// Note: the expected value comes before the actual value in the argument list.
+func (ta *TypedAsserter[T]) NotEqual(unexpected T, actual T, args ...any) bool {
+	return NotEqualT(ta.tt, unexpected, actual, args...)
+}
+
+// Contains fails the test if the slice does not contain the element.
+func (ta *TypedAsserter[T]) Contains(slice []T, element T, args ...any) bool {
+	return ContainsT(ta.tt, slice, element, args...)
+}
+
+// NotContains fails the test if the slice contains the element.
+func (ta *TypedAsserter[T]) NotContains(slice []T, element T, args ...any) bool {
+	return NotContainsT(ta.tt, slice, element, args...)
+}
+
+// Len fails the test if the length of the slice does not match the expected len.
+func (ta *TypedAsserter[T]) Len(slice []T, length int, args ...any) bool {
+	return LenT(ta.tt, slice, length, args...)
+}
+
+// equalT compares the two values with ==.
+// An interface type is comparable, but comparing interfaces that hold values of an uncomparable type,
+// such as slices, panics, in which case the values are compared with reflect.DeepEqual.
+func equalT[T comparable](a T, b T) (equal bool) {
+	defer func() {
+		if recover() != nil {
+			equal = reflect.DeepEqual(a, b)
+		}
+	}()
+	return a == b
+}
+
+// containsT indicates if the slice contains the element, as compared by equalT.
+func containsT[S ~[]E, E comparable](slice S, element E) bool {
+	return slices.ContainsFunc(slice, func(e E) bool { return equalT(e, element) })
+}
+
+// hasKeyT indicates if the map contains the key.
+// A key of an uncomparable type held by an interface cannot be in the map, and looking it up panics.
+func hasKeyT[M ~map[K]V, K comparable, V any](m M, key K) (ok bool) {
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+	}()
+	_, ok = m[key]
+	return ok
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2024-2025 Microbus LLC and various contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testarossa
+
+import (
+	"testing"
+)
+
+func Test_EqualT(t *testing.T) {
+	mt := &MockTestingT{}
+
+	if !EqualT(mt, 1, 1) || mt.Failed() {
+		t.FailNow()
+	}
+	if EqualT(mt, 1, 0, "You are not the %d", 1) || mt.Passed() {
+		t.FailNow()
+	}
+	if !EqualT(mt, int64(1), 1) || mt.Failed() {
+		t.FailNow()
+	}
+	if !EqualT(mt, "foo", "foo") || mt.Failed() {
+		t.FailNow()
+	}
+
+	type E struct {
+		X int
+	}
+	if !EqualT(mt, E{1}, E{1}) || mt.Failed() {
+		t.FailNow()
+	}
+	if EqualT(mt, E{1}, E{2}) || mt.Passed() {
+		t.FailNow()
+	}
+
+	var ptr *E
+	if !EqualT(mt, nil, ptr) || mt.Failed() {
+		t.FailNow()
+	}
+
+	if !NotEqualT(mt, 1, 0) || mt.Failed() {
+		t.FailNow()
+	}
+	if NotEqualT(mt, "foo", "foo") || mt.Passed() {
+		t.FailNow()
+	}
+}
+
+func Test_ContainsT(t *testing.T) {
+	mt := &MockTestingT{}
+
+	if !ContainsT(mt, []int{1, 2, 3}, 3) || mt.Failed() {
+		t.FailNow()
+	}
+	if ContainsT(mt, []int{1, 2, 3}, 4) || mt.Passed() {
+		t.FailNow()
+	}
+	if ContainsT(mt, []int(nil), 4) || mt.Passed() {
+		t.FailNow()
+	}
+	if !NotContainsT(mt, []int{1, 2, 3}, 4) || mt.Failed() {
+		t.FailNow()
+	}
+	if NotContainsT(mt, []int{1, 2, 3}, 3) || mt.Passed() {
+		t.FailNow()
+	}
+
+	type IDs []string
+	if !ContainsT(mt, IDs{"a", "b"}, "a") || mt.Failed() {
+		t.FailNow()
+	}
+}
+
+func Test_KeyT(t *testing.T) {
+	mt := &MockTestingT{}
+
+	m := map[string]int{"x": 1, "y": 2}
+	if !KeyT(mt, m, "x") || mt.Failed() {
+		t.FailNow()
+	}
+	if KeyT(mt, m, "z") || mt.Passed() {
+		t.FailNow()
+	}
+	if !NotKeyT(mt, m, "z") || mt.Failed() {
+		t.FailNow()
+	}
+	if NotKeyT(mt, m, "y") || mt.Passed() {
+		t.FailNow()
+	}
+
+	var nilMap map[string]int
+	if KeyT(mt, nilMap, "x") || mt.Passed() {
+		t.FailNow()
+	}
+}
+
+func Test_LenT(t *testing.T) {
+	mt := &MockTestingT{}
+
+	if !LenT(mt, []int{1, 2, 3}, 3) || mt.Failed() {
+		t.FailNow()
+	}
+	if LenT(mt, []int{1, 2, 3}, 2) || mt.Passed() {
+		t.FailNow()
+	}
+	if !LenT(mt, []string(nil), 0) || mt.Failed() {
+		t.FailNow()
+	}
+}
+
+func Test_Typed(t *testing.T) {
+	mt := &MockTestingT{}
+	tt := For(mt)
+
+	ti := Typed[int](tt)
+	if !ti.Equal(1, 1) || mt.Failed() {
+		t.FailNow()
+	}
+	if ti.Equal(1, 2) || mt.Passed() {
+		t.FailNow()
+	}
+	if !ti.NotEqual(1, 2) || mt.Failed() {
+		t.FailNow()
+	}
+	if !ti.Contains([]int{1, 2}, 2) || mt.Failed() {
+		t.FailNow()
+	}
+	if !ti.NotContains([]int{1, 2}, 3) || mt.Failed() {
+		t.FailNow()
+	}
+	if !ti.Len([]int{1, 2}, 2) || mt.Failed() {
+		t.FailNow()
+	}
+	if ti.Len([]int{1, 2}, 3) || mt.Passed() {
+		t.FailNow()
+	}
+}
+
+func Test_GenericUncomparable(t *testing.T) {
+	mt := &MockTestingT{}
+	if !EqualT[any](mt, []int{1}, []int{1}) || mt.Failed() {
+		t.FailNow()
+	}
+	if EqualT[any](mt, []int{1}, []int{2}) || mt.Passed() {
+		t.FailNow()
+	}
+	if NotEqualT[any](mt, []int{1}, []int{1}) || mt.Passed() {
+		t.FailNow()
+	}
+	if !ContainsT(mt, []any{1, []int{1}}, any([]int{1})) || mt.Failed() {
+		t.FailNow()
+	}
+	if !NotContainsT(mt, []any{[]int{1}}, any([]int{2})) || mt.Failed() {
+		t.FailNow()
+	}
+	if KeyT(mt, map[any]int{1: 1}, any([]int{1})) || mt.Passed() {
+		t.FailNow()
+	}
+	if !NotKeyT(mt, map[any]int{1: 1}, any([]int{1})) || mt.Failed() {
+		t.FailNow()
+	}
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2024-2025 Microbus LLC and various contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testarossa
+
+import (
+	"fmt"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// leakGracePeriod is how long leak checks wait for resources to be released before failing the test.
+var leakGracePeriod = time.Second
+
+// ignoredGoroutines are the prefixes of the functions of goroutines that belong to the runtime and the testing package.
+var ignoredGoroutines = []string{
+	"testing.tRunner",
+	"testing.(*T).Run",
+	"testing.runTests",
+	"testing.(*M).",
+	"testing.runFuzzing",
+	"os/signal.",
+	"runtime.ensureSigM",
+	"runtime/trace.",
+}
+
+// goroutine is a goroutine parsed from the output of runtime.Stack.
+type goroutine struct {
+	id     int
+	state  string
+	funcs  []string
+	frames []frame
+}
+
+/*
+NoGoroutineLeaks takes a snapshot of the running goroutines and returns a function that fails the test
+if goroutines that were started after the snapshot are still running.
+Goroutines are given a grace period to exit, during which the check is retried.
+Goroutines with a function in their stack that contains any of the ignore strings are not considered leaked,
+nor are those of the runtime and the testing package.
+The function can be deferred or registered as a cleanup function.
+
+	defer testarossa.NoGoroutineLeaks(t)()
+	t.Cleanup(testarossa.NoGoroutineLeaks(t, "go.opencensus.io/stats/view.(*worker).start"))
+*/
+func NoGoroutineLeaks(t TestingT, ignore ...string) func() {
+	return checkAtEnd(t, "Leaked %d goroutine(s)", true, []LeakCheck{Goroutines(ignore...)})
+}
+
+// Goroutines checks for goroutines that are still running.
+// Goroutines with a function in their stack that contains any of the ignore strings are not considered leaked,
+// nor are those of the runtime and the testing package.
+func Goroutines(ignore ...string) LeakCheck {
+	return func() func() []string {
+		before := map[int]bool{}
+		for _, g := range runningGoroutines() {
+			before[g.id] = true
+		}
+		return func() (leaked []string) {
+			for _, g := range runningGoroutines() {
+				if !before[g.id] && !g.ignored(ignore) {
+					leaked = append(leaked, g.String())
+				}
+			}
+			return leaked
+		}
+	}
+}
+
+// retryLeakCheck runs the check until it finds no leaks or until the grace period elapses.
+// It returns the leaks found by the last run.
+func retryLeakCheck(check func() []any) (leaked []any) {
+	deadline := time.Now().Add(leakGracePeriod)
+	delay := time.Millisecond
+	for {
+		leaked = check()
+		if len(leaked) == 0 || time.Now().After(deadline) {
+			return leaked
+		}
+		time.Sleep(delay)
+		delay = min(2*delay, 100*time.Millisecond)
+	}
+}
+
+// runningGoroutines returns all goroutines other than the current one.
+func runningGoroutines() []goroutine {
+	buf := make([]byte, 64*1024)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+	var goroutines []goroutine
+	for i, block := range strings.Split(string(buf), "\n\n") {
+		g, ok := parseGoroutine(block)
+		// The current goroutine is listed first
+		if ok && i > 0 {
+			goroutines = append(goroutines, g)
+		}
+	}
+	return goroutines
+}
+
+/*
+parseGoroutine parses the stack of a goroutine as printed by runtime.Stack:
+
+	goroutine 7 [chan receive]:
+	main.worker(...)
+		/src/main.go:12 +0x1d
+	created by main.main in goroutine 1
+		/src/main.go:30 +0x25
+*/
+func parseGoroutine(block string) (g goroutine, ok bool) {
+	lines := strings.Split(strings.TrimSpace(block), "\n")
+	header, found := strings.CutPrefix(lines[0], "goroutine ")
+	if !found {
+		return g, false
+	}
+	id, state, _ := strings.Cut(header, " ")
+	var err error
+	g.id, err = strconv.Atoi(id)
+	if err != nil {
+		return g, false
+	}
+	g.state = strings.TrimSuffix(strings.TrimPrefix(state, "["), "]:")
+	for i := 1; i+1 < len(lines); i += 2 {
+		funcName := lines[i]
+		if p := strings.LastIndex(funcName, "("); p > 0 && strings.HasSuffix(funcName, ")") {
+			funcName = funcName[:p]
+		}
+		if createdBy, found := strings.CutPrefix(funcName, "created by "); found {
+			funcName, _, _ = strings.Cut(createdBy, " in goroutine ")
+		}
+		location := strings.TrimSpace(lines[i+1])
+		if p := strings.LastIndex(location, " +0x"); p > 0 {
+			location = location[:p]
+		}
+		p := strings.LastIndex(location, ":")
+		if p < 0 {
+			continue
+		}
+		line, _ := strconv.Atoi(location[p+1:])
+		g.funcs = append(g.funcs, funcName)
+		g.frames = append([]frame{{file: location[:p], line: line}}, g.frames...)
+	}
+	return g, true
+}
+
+// ignored indicates if any of the functions of the goroutine contains any of the ignore strings,
+// or belongs to the runtime or the testing package.
+func (g goroutine) ignored(ignore []string) bool {
+	for _, funcName := range g.funcs {
+		for _, prefix := range ignoredGoroutines {
+			if strings.HasPrefix(funcName, prefix) {
+				return true
+			}
+		}
+		if slices.ContainsFunc(ignore, func(s string) bool { return strings.Contains(funcName, s) }) {
+			return true
+		}
+	}
+	return false
+}
+
+// String formats the goroutine as its header followed by the frames of its stack, from the outermost to the innermost.
+// Frames of the runtime are omitted.
+func (g goroutine) String() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("goroutine %d [%s]", g.id, g.state))
+	for i, f := range g.frames {
+		if strings.HasPrefix(g.funcs[len(g.funcs)-1-i], "runtime.") {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("\n    %s:%d", f.file, f.line))
+	}
+	return sb.String()
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2024-2025 Microbus LLC and various contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testarossa
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func blockUntilClosed(ch chan struct{}) {
+	<-ch
+}
+
+func TestGoroutines_Leak(t *testing.T) {
+	leakGracePeriod = 50 * time.Millisecond
+	defer func() { leakGracePeriod = time.Second }()
+
+	rt := &RecordingTestingT{}
+	check := NoGoroutineLeaks(rt)
+	ch := make(chan struct{})
+	go blockUntilClosed(ch)
+	check()
+	if rt.Passed() {
+		t.FailNow()
+	}
+	if !strings.HasPrefix(rt.message, "Leaked 1 goroutine(s)\ngoroutine ") || !strings.Contains(rt.message, "[chan receive]\n    ") {
+		t.Fatal(rt.message)
+	}
+	if !strings.Contains(rt.message, "goroutines_test.go:") || strings.Contains(rt.message, "/runtime/") {
+		t.Fatal(rt.message)
+	}
+	if !strings.HasSuffix(rt.file, "goroutines_test.go") {
+		t.FailNow()
+	}
+
+	// Ignored
+	check = For(rt).NoGoroutineLeaks("testarossa.blockUntilClosed")
+	go blockUntilClosed(ch)
+	check()
+	if rt.Failed() {
+		t.FailNow()
+	}
+	close(ch)
+}
+
+func TestGoroutines_GracePeriod(t *testing.T) {
+	mt := &MockTestingT{}
+	check := NoGoroutineLeaks(mt)
+	ch := make(chan struct{})
+	go blockUntilClosed(ch)
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		close(ch)
+	}()
+	check()
+	if mt.Failed() {
+		t.FailNow()
+	}
+}
+
+func TestGoroutines_Cleanup(t *testing.T) {
+	t.Run("cleanup", func(t *testing.T) {
+		t.Cleanup(NoGoroutineLeaks(t))
+		done := make(chan struct{})
+		go func() {
+			close(done)
+		}()
+		<-done
+	})
+}
+
+func TestGoroutines_Parse(t *testing.T) {
+	g, ok := parseGoroutine("goroutine 7 [chan receive]:\n" +
+		"main.worker(0xc000010000)\n" +
+		"\t/src/main.go:12 +0x1d\n" +
+		"runtime.gopark(...)\n" +
+		"\t/go/src/runtime/proc.go:400\n" +
+		"created by main.main in goroutine 1\n" +
+		"\t/src/main.go:30 +0x25\n")
+	if !ok || g.id != 7 || g.state != "chan receive" || len(g.frames) != 3 {
+		t.FailNow()
+	}
+	if g.funcs[0] != "main.worker" || g.funcs[2] != "main.main" || g.frames[0].line != 30 {
+		t.FailNow()
+	}
+	if g.String() != "goroutine 7 [chan receive]\n    /src/main.go:30\n    /src/main.go:12" {
+		t.Fatal(g.String())
+	}
+	if !g.ignored([]string{"worker"}) || g.ignored([]string{"other"}) {
+		t.FailNow()
+	}
+	_, ok = parseGoroutine("garbage")
+	if ok {
+		t.FailNow()
+	}
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2024-2025 Microbus LLC and various contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testarossa
+
+import (
+	"slices"
+	"sync"
+)
+
+// Failure describes a failed assertion to the failure hooks.
+type Failure struct {
+	// Test is the name of the test
+	Test string
+	// Frames lead to the failed assertion, from the outermost to the innermost
+	Frames []Frame
+	// Message is the failure message, as printed
+	Message string
+	// Expected and Actual are the expected and actual values, as shown in failure messages but not truncated,
+	// if the assertion compares the two.
+	// If it compares several pairs, such as the values at different keys of a map, these are those of the first pair
+	Expected string
+	Actual   string
+	// Labels are the labels of the Asserter that made the failed assertion
+	Labels []Label
+}
+
+// Frame is a location in the source code.
+type Frame struct {
+	File string
+	Line int
+}
+
+var (
+	// globalHooks are the failure hooks of all tests, keyed by their registration.
+	globalHooks    []*func(f Failure)
+	globalHooksMux sync.Mutex
+)
+
+/*
+OnFailure registers a hook that is called whenever an assertion fails.
+If t is an Asserter, the hook is called for failures of that Asserter, and of the Asserters derived from it.
+Otherwise, the hook is called for failures of the test, and of its subtests run by Table or Run,
+provided that the test supports cleanup functions, as *testing.T and *testing.B do.
+Hooks are called after the failure is printed and before the test is failed,
+and so before FatalIf stops the test.
+
+	testarossa.OnFailure(t, func(f testarossa.Failure) {
+		dumpTables(t, db)
+	})
+*/
+func OnFailure(t TestingT, hook func(f Failure)) {
+	if tt, ok := t.(*Asserter); ok {
+		tt.hooksMux.Lock()
+		tt.hooks = append(tt.hooks, hook)
+		tt.hooksMux.Unlock()
+		return
+	}
+	state := stateOf(t)
+	if state == nil {
+		return
+	}
+	state.mux.Lock()
+	state.hooks = append(state.hooks, hook)
+	state.mux.Unlock()
+}
+
+/*
+OnAnyFailure registers a hook that is called whenever an assertion of any test fails.
+It returns a function that unregisters the hook.
+
+	func TestMain(m *testing.M) {
+		unregister := testarossa.OnAnyFailure(func(f testarossa.Failure) {
+			log.Printf("%s failed: %s", f.Test, f.Message)
+		})
+		code := m.Run()
+		unregister()
+		os.Exit(code)
+	}
+*/
+func OnAnyFailure(hook func(f Failure)) (unregister func()) {
+	globalHooksMux.Lock()
+	globalHooks = append(globalHooks, &hook)
+	globalHooksMux.Unlock()
+	return func() {
+		globalHooksMux.Lock()
+		globalHooks = slices.DeleteFunc(globalHooks, func(h *func(f Failure)) bool { return h == &hook })
+		globalHooksMux.Unlock()
+	}
+}
+
+// runFailureHooks calls the global hooks, the hooks of the test and of its parent tests,
+// and the hooks of the Asserter and of the Asserters it is derived from, in this order.
+func runFailureHooks(t TestingT, frames []frame, message string, labels []Label, args []any) {
+	var hooks []func(f Failure)
+	globalHooksMux.Lock()
+	for _, hook := range globalHooks {
+		hooks = append(hooks, *hook)
+	}
+	globalHooksMux.Unlock()
+	var testHooks []func(f Failure)
+	for state := stateOf(t); state != nil; {
+		state.mux.Lock()
+		testHooks = append(slices.Clone(state.hooks), testHooks...)
+		parent := state.parent
+		state.mux.Unlock()
+		state = parent
+	}
+	hooks = append(hooks, testHooks...)
+	hooks = append(hooks, hooksOf(t)...)
+	if len(hooks) == 0 {
+		return
+	}
+
+	f := Failure{
+		Test:    t.Name(),
+		Message: message,
+		Labels:  labels,
+	}
+	for _, fr := range frames {
+		f.Frames = append(f.Frames, Frame{File: fr.file, Line: fr.line})
+	}
+	for _, arg := range args {
+		if d, ok := arg.(diffOffset); ok {
+			f.Expected, f.Actual = d.expected, d.actual
+			break
+		}
+	}
+	for _, hook := range hooks {
+		hook(f)
+	}
+}
+
+// hooksOf returns the failure hooks of the Asserter, and of the Asserters it is derived from, from the outermost to the innermost.
+func hooksOf(t TestingT) (hooks []func(f Failure)) {
+	for {
+		tt, ok := t.(*Asserter)
+		if !ok {
+			return hooks
+		}
+		tt.hooksMux.Lock()
+		hooks = append(slices.Clone(tt.hooks), hooks...)
+		tt.hooksMux.Unlock()
+		t = tt.t
+	}
+}
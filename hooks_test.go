@@ -0,0 +1,185 @@
+/*
+Copyright 2024-2025 Microbus LLC and various contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testarossa
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHooks_Asserter(t *testing.T) {
+	mt := &MockTestingT{}
+	tt := For(mt)
+	var failures []Failure
+	tt.OnFailure(func(f Failure) {
+		failures = append(failures, f)
+	})
+	tt.With("tenant", "acme").Equal(1, 2, "Custom")
+	if len(failures) != 1 {
+		t.FailNow()
+	}
+	f := failures[0]
+	if f.Test != "Mock" || f.Message != "Expected '1', actual '2'\nCustom" || f.Expected != "1" || f.Actual != "2" {
+		t.FailNow()
+	}
+	if len(f.Labels) != 1 || f.Labels[0].Key != "tenant" {
+		t.FailNow()
+	}
+	if len(f.Frames) == 0 || !strings.HasSuffix(f.Frames[len(f.Frames)-1].File, "hooks_test.go") {
+		t.FailNow()
+	}
+
+	// Not for the parent Asserter or the test
+	child := For(tt)
+	var childFailures int
+	OnFailure(child, func(f Failure) {
+		childFailures++
+	})
+	tt.True(false)
+	True(mt, false)
+	if len(failures) != 2 || childFailures != 0 {
+		t.FailNow()
+	}
+	child.Contains("abc", "x")
+	if len(failures) != 3 || childFailures != 1 || failures[2].Expected != "" {
+		t.FailNow()
+	}
+}
+
+// FatalTestingT records whether the failure hook was called by the time FailNow is called.
+type FatalTestingT struct {
+	MockTestingT
+	hookCalled      bool
+	hookCalledFirst bool
+}
+
+func (ft *FatalTestingT) FailNow() {
+	ft.hookCalledFirst = ft.hookCalled
+	ft.MockTestingT.FailNow()
+}
+
+func TestHooks_BeforeFailNow(t *testing.T) {
+	ft := &FatalTestingT{}
+	tt := For(ft)
+	tt.OnFailure(func(f Failure) {
+		ft.hookCalled = true
+	})
+	FatalIf(tt, true, "Fatal")
+	if !ft.hookCalledFirst {
+		t.FailNow()
+	}
+}
+
+func TestHooks_Test(t *testing.T) {
+	ct := &CleaningTestingT{}
+	var failures []string
+	OnFailure(ct, func(f Failure) {
+		failures = append(failures, f.Message)
+	})
+	Equal(ct, 1, 2)
+	For(ct).True(false, "Not true")
+	if len(failures) != 2 || failures[1] != "Expected condition to be true\nNot true" {
+		t.FailNow()
+	}
+	ct.cleanup()
+	Equal(ct, 1, 2)
+	if len(failures) != 2 {
+		t.FailNow()
+	}
+
+	// No effect without cleanup functions
+	mt := &MockTestingT{}
+	OnFailure(mt, func(f Failure) {
+		t.FailNow()
+	})
+	Equal(mt, 1, 2)
+}
+
+func TestHooks_Subtests(t *testing.T) {
+	var failed []string
+	OnFailure(t, func(f Failure) {
+		failed = append(failed, f.Test)
+	})
+	tt := For(t)
+	var asserterFailed []string
+	tt.OnFailure(func(f Failure) {
+		asserterFailed = append(asserterFailed, f.Test)
+	})
+	tt.Run("sub", func(tt *Asserter) {
+		// A failure would fail the test, so the hooks are run directly
+		runFailureHooks(tt, nil, "", nil, nil)
+	})
+	if len(failed) != 1 || failed[0] != "TestHooks_Subtests/sub" || len(asserterFailed) != 1 {
+		t.Fatal(failed, asserterFailed)
+	}
+}
+
+func TestHooks_Global(t *testing.T) {
+	mt := &MockTestingT{}
+	var count int
+	unregister := OnAnyFailure(func(f Failure) {
+		count++
+	})
+	Equal(mt, 1, 2)
+	unregister()
+	Equal(mt, 1, 2)
+	if count != 1 {
+		t.FailNow()
+	}
+}
+
+func TestHooks_ExpectedActual(t *testing.T) {
+	mt := &MockTestingT{}
+	tt := For(mt)
+	var failures []Failure
+	tt.OnFailure(func(f Failure) {
+		failures = append(failures, f)
+	})
+	long := strings.Repeat("x", 1000)
+	EqualT(tt, long+"1", long+"2")
+	ContainsEntry(tt, map[string]int{"k": 2}, "k", 1)
+	MapSubset(tt, map[string]int{"a": 2, "b": 4}, map[string]int{"a": 1, "b": 3})
+	ch := make(chan int, 1)
+	ch <- 2
+	ReceivesEqual(tt, ch, 1, 0)
+	if len(failures) != 4 {
+		t.FailNow()
+	}
+	if failures[0].Expected != long+"1" || failures[0].Actual != long+"2" {
+		t.FailNow()
+	}
+	for _, f := range failures[1:] {
+		if f.Expected != "1" || f.Actual != "2" {
+			t.Fatal(f)
+		}
+	}
+}
+
+func TestHooks_SubtestsWithoutSubtestSupport(t *testing.T) {
+	mt := &MockTestingT{}
+	tt := For(mt)
+	var count int
+	tt.OnFailure(func(f Failure) {
+		count++
+	})
+	tt.Run("sub", func(tt *Asserter) {
+		tt.True(false)
+	})
+	if count != 1 {
+		t.Fatal(count)
+	}
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2024-2025 Microbus LLC and various contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testarossa
+
+import (
+	"iter"
+	"reflect"
+)
+
+// seqLimit caps the number of items consumed from an iterator, guarding against infinite sequences.
+const seqLimit = 1 << 16
+
+// SeqEqual fails the test if the iterator does not yield the same elements as the slice, in the same order.
+// Note: the expected value comes before the actual value in the argument list.
+func SeqEqual[V any](t TestingT, expected []V, actual iter.Seq[V], args ...any) bool {
+	countAssertion(t)
+	var items []any
+	pos := -1
+	if actual != nil {
+		for item := range actual {
+			items = append(items, item)
+			if len(items) > len(expected) || !reflect.DeepEqual(expected[len(items)-1], item) {
+				pos = len(items) - 1
+				break
+			}
+		}
+	}
+	if pos < 0 && len(items) == len(expected) {
+		return true
+	}
+	var msgArgs []any
+	switch {
+	case pos < 0:
+		pos = len(items)
+		msgArgs = []any{"Expected %d items, actual sequence ended after %d", len(expected), len(items)}
+	case pos >= len(expected):
+		msgArgs = []any{"Expected %d items, actual sequence has more", len(expected)}
+	default:
+		expectedArg, actualArg, offsetArg := diffArgs(expected[pos], items[pos])
+		msgArgs = []any{"Sequences differ at position %d: expected '%v', actual '%v'", pos, expectedArg, actualArg, offsetArg}
+	}
+	msgArgs = append(msgArgs, "Excerpt %s", excerptElements(items, pos))
+	fail(
+		t,
+		true,
+		append(msgArgs, args...)...,
+	)
+	return false
+}
+
+// seqArity returns 1 if the type is an iter.Seq, 2 if the type is an iter.Seq2, or 0 otherwise.
+// Any named or unnamed function type of the same shape is recognized.
+func seqArity(typ reflect.Type) int {
+	if typ.Kind() != reflect.Func || typ.NumIn() != 1 || typ.NumOut() != 0 {
+		return 0
+	}
+	yield := typ.In(0)
+	if yield.Kind() != reflect.Func || yield.NumOut() != 1 || yield.Out(0).Kind() != reflect.Bool {
+		return 0
+	}
+	if yield.NumIn() != 1 && yield.NumIn() != 2 {
+		return 0
+	}
+	return yield.NumIn()
+}
+
+// seqPair is a key/value pair yielded by an iter.Seq2.
+type seqPair struct {
+	key   any
+	value any
+}
+
+// String renders the pair as key:value, similar to how fmt renders map entries.
+func (sp seqPair) String() string {
+	return render(sp.key) + ":" + render(sp.value)
+}
+
+// seqItems collects the items yielded by the iterator until the match function returns true.
+// The items of an iter.Seq2 are collected as key/value pairs, and the match function is given the key.
+// Consumption stops after the limit, in which case exceeded is true.
+func seqItems(seq reflect.Value, limit int, match func(key any) bool) (items []any, matched bool, exceeded bool) {
+	if seq.IsNil() {
+		return nil, false, false
+	}
+	yield := reflect.MakeFunc(seq.Type().In(0), func(in []reflect.Value) []reflect.Value {
+		if len(items) >= limit {
+			exceeded = true
+			return []reflect.Value{reflect.ValueOf(false)}
+		}
+		key := in[0].Interface()
+		if len(in) > 1 {
+			items = append(items, seqPair{key: key, value: in[1].Interface()})
+		} else {
+			items = append(items, key)
+		}
+		matched = match != nil && match(key)
+		return []reflect.Value{reflect.ValueOf(!matched)}
+	})
+	seq.Call([]reflect.Value{yield})
+	return items, matched, exceeded
+}
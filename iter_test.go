@@ -0,0 +1,153 @@
+/*
+Copyright 2024-2025 Microbus LLC and various contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testarossa
+
+import (
+	"iter"
+	"maps"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func infiniteSeq() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+func Test_ContainsSeq(t *testing.T) {
+	mt := &MockTestingT{}
+
+	seq := slices.Values([]string{"a", "b", "c"})
+	if !Contains(mt, seq, "b") || mt.Failed() {
+		t.FailNow()
+	}
+	if Contains(mt, seq, "x") || mt.Passed() {
+		t.FailNow()
+	}
+	if !NotContains(mt, seq, "x") || mt.Failed() {
+		t.FailNow()
+	}
+	if NotContains(mt, seq, "a") || mt.Passed() {
+		t.FailNow()
+	}
+
+	seq2 := maps.All(map[string]int{"a": 1, "b": 2})
+	if !Contains(mt, seq2, "a") || mt.Failed() {
+		t.FailNow()
+	}
+	if Contains(mt, seq2, 1) || mt.Passed() {
+		t.FailNow()
+	}
+	if !NotContains(mt, seq2, "c") || mt.Failed() {
+		t.FailNow()
+	}
+
+	// Infinite sequences
+	if !Contains(mt, infiniteSeq(), 1000) || mt.Failed() {
+		t.FailNow()
+	}
+	if Contains(mt, infiniteSeq(), -1) || mt.Passed() {
+		t.FailNow()
+	}
+	if NotContains(mt, infiniteSeq(), -1) || mt.Passed() {
+		t.FailNow()
+	}
+
+	var nilSeq iter.Seq[int]
+	if Contains(mt, nilSeq, 1) || mt.Passed() {
+		t.FailNow()
+	}
+}
+
+func Test_LenSeq(t *testing.T) {
+	mt := &MockTestingT{}
+
+	if !Len(mt, slices.Values([]int{1, 2, 3}), 3) || mt.Failed() {
+		t.FailNow()
+	}
+	if Len(mt, slices.Values([]int{1, 2, 3}), 2) || mt.Passed() {
+		t.FailNow()
+	}
+	if !Len(mt, maps.Keys(map[int]int{1: 1, 2: 2}), 2) || mt.Failed() {
+		t.FailNow()
+	}
+	if !Len(mt, slices.All([]int{1, 2, 3}), 3) || mt.Failed() {
+		t.FailNow()
+	}
+	if Len(mt, infiniteSeq(), 3) || mt.Passed() {
+		t.FailNow()
+	}
+
+	var nilSeq iter.Seq[int]
+	if !Len(mt, nilSeq, 0) || mt.Failed() {
+		t.FailNow()
+	}
+
+	// Not an iterator
+	if Len(mt, func(int) {}, 0) || mt.Passed() {
+		t.FailNow()
+	}
+}
+
+func Test_SeqEqual(t *testing.T) {
+	mt := &MockTestingT{}
+
+	if !SeqEqual(mt, []int{1, 2, 3}, slices.Values([]int{1, 2, 3})) || mt.Failed() {
+		t.FailNow()
+	}
+	if !SeqEqual(mt, nil, slices.Values([]int{})) || mt.Failed() {
+		t.FailNow()
+	}
+	if !SeqEqual(mt, []int{}, nil) || mt.Failed() {
+		t.FailNow()
+	}
+	if SeqEqual(mt, []int{1, 2, 3}, slices.Values([]int{1, 5, 3})) || mt.Passed() {
+		t.FailNow()
+	}
+	if SeqEqual(mt, []int{1, 2, 3}, slices.Values([]int{1, 2})) || mt.Passed() {
+		t.FailNow()
+	}
+	if SeqEqual(mt, []int{1, 2, 3}, infiniteSeq()) || mt.Passed() {
+		t.FailNow()
+	}
+	if !SeqEqual(mt, []int{0, 1, 2}, func(yield func(int) bool) {
+		for i := range 3 {
+			if !yield(i) {
+				return
+			}
+		}
+	}) || mt.Failed() {
+		t.FailNow()
+	}
+}
+
+func Test_SeqArity(t *testing.T) {
+	tt := For(t)
+
+	tt.Equal(1, seqArity(reflect.TypeOf(slices.Values([]int{}))))
+	tt.Equal(2, seqArity(reflect.TypeOf(slices.All([]int{}))))
+	tt.Equal(0, seqArity(reflect.TypeOf(func() {})))
+	tt.Equal(0, seqArity(reflect.TypeOf(func(func(int)) {})))
+	tt.Equal(0, seqArity(reflect.TypeOf(func(func(int, int, int) bool) {})))
+	tt.Equal(0, seqArity(reflect.TypeOf(1)))
+}
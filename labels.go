@@ -0,0 +1,96 @@
+/*
+Copyright 2024-2025 Microbus LLC and various contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testarossa
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// badKey is the key of a label whose value is missing its key.
+const badKey = "!BADKEY"
+
+// Label is a key/value pair that describes the context of a failed assertion.
+type Label struct {
+	Key   string
+	Value any
+}
+
+// String renders the label as key=value.
+// The value is quoted if it is empty or contains whitespace, quotes or an equal sign.
+func (l Label) String() string {
+	val := render(l.Value)
+	if val == "" || strings.ContainsAny(val, " \t\r\n\"=") {
+		val = strconv.Quote(val)
+	}
+	return l.Key + "=" + val
+}
+
+/*
+With returns an Asserter for the same test that labels all its failures with the key/value pairs.
+Labels nest, so that the labels of this Asserter are followed by the new labels.
+
+	for _, tenant := range tenants {
+		tt := tt.With("tenant", tenant.ID)
+		for shard := range tenant.Shards {
+			tt := tt.With("shard", shard)
+			tt.NoError(check(tenant, shard))
+		}
+	}
+*/
+func (tt *Asserter) With(keyValues ...any) *Asserter {
+	return &Asserter{
+		t:      tt,
+		labels: labelsOf(keyValues),
+	}
+}
+
+// labelsOf converts alternating keys and values to labels.
+// A value without a key is labeled with the key !BADKEY.
+func labelsOf(keyValues []any) []Label {
+	var labels []Label
+	for i := 0; i < len(keyValues); i += 2 {
+		if i+1 == len(keyValues) {
+			labels = append(labels, Label{Key: badKey, Value: keyValues[i]})
+			break
+		}
+		labels = append(labels, Label{Key: fmt.Sprint(keyValues[i]), Value: keyValues[i+1]})
+	}
+	return labels
+}
+
+// labelsOfT returns the labels of the Asserter, and of the Asserters it is derived from, from the outermost to the innermost.
+func labelsOfT(t TestingT) (labels []Label) {
+	for {
+		tt, ok := t.(*Asserter)
+		if !ok {
+			return labels
+		}
+		labels = append(append([]Label{}, tt.labels...), labels...)
+		t = tt.t
+	}
+}
+
+// formatLabels renders the labels as space-separated key=value pairs.
+func formatLabels(labels []Label) string {
+	parts := make([]string, len(labels))
+	for i, l := range labels {
+		parts[i] = l.String()
+	}
+	return strings.Join(parts, " ")
+}
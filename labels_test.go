@@ -0,0 +1,111 @@
+/*
+Copyright 2024-2025 Microbus LLC and various contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testarossa
+
+import (
+	"testing"
+)
+
+func TestLabels_String(t *testing.T) {
+	testCases := []struct {
+		label    Label
+		expected string
+	}{
+		{Label{"tenant", "acme"}, `tenant=acme`},
+		{Label{"shard", 3}, `shard=3`},
+		{Label{"name", "two words"}, `name="two words"`},
+		{Label{"empty", ""}, `empty=""`},
+		{Label{"eq", "a=b"}, `eq="a=b"`},
+		{Label{"nil", nil}, `nil=<nil>`},
+	}
+	for _, tc := range testCases {
+		if tc.label.String() != tc.expected {
+			t.Errorf("expected %s, actual %s", tc.expected, tc.label.String())
+		}
+	}
+}
+
+func TestLabels_With(t *testing.T) {
+	rt := &RecordingTestingT{}
+	tt := For(rt)
+
+	tenant := tt.With("tenant", "acme")
+	shard := tenant.With("shard", 3)
+	if shard.Equal(1, 2) || rt.Passed() {
+		t.FailNow()
+	}
+	if len(rt.labels) != 2 || rt.labels[0] != (Label{"tenant", "acme"}) || rt.labels[1] != (Label{"shard", 3}) {
+		t.FailNow()
+	}
+	if tenant.Equal(1, 2) || rt.Passed() {
+		t.FailNow()
+	}
+	if len(rt.labels) != 1 || rt.labels[0] != (Label{"tenant", "acme"}) {
+		t.FailNow()
+	}
+	if tt.Equal(1, 2) || rt.Passed() {
+		t.FailNow()
+	}
+	if len(rt.labels) != 0 {
+		t.FailNow()
+	}
+
+	// Key without a value
+	if tt.With("a", 1, "orphan").Equal(1, 2) || rt.Passed() {
+		t.FailNow()
+	}
+	if len(rt.labels) != 2 || rt.labels[1] != (Label{badKey, "orphan"}) {
+		t.FailNow()
+	}
+
+	// Options and labels combine
+	if tt.With("a", 1).WithOptions(MaxLen(2)).Equal("xxxx", "yyyy") || rt.Passed() {
+		t.FailNow()
+	}
+	if len(rt.labels) != 1 || rt.message != "Expected 'xx…', actual 'yy…'\nFirst difference at offset 0" {
+		t.FailNow()
+	}
+}
+
+func TestLabels_Subtests(t *testing.T) {
+	var labels []Label
+	For(t).With("tenant", "acme").Run("sub", func(tt *Asserter) {
+		labels = labelsOfT(tt.With("shard", 3))
+	})
+	if len(labels) != 2 || labels[0].Key != "tenant" || labels[1].Key != "shard" {
+		t.FailNow()
+	}
+	if formatLabels(labels) != "tenant=acme shard=3" {
+		t.FailNow()
+	}
+}
+
+func TestLabels_SubtestsWithoutSubtestSupport(t *testing.T) {
+	rt := &RecordingTestingT{}
+	passed := For(rt).With("tenant", 7).WithOptions(MaxLen(2)).Run("sub", func(tt *Asserter) {
+		tt.Equal("xxxx", "yyyy")
+	})
+	if passed || rt.Passed() {
+		t.FailNow()
+	}
+	if len(rt.labels) != 1 || rt.labels[0] != (Label{"tenant", 7}) {
+		t.FailNow()
+	}
+	if rt.message != "Expected 'xx…', actual 'yy…'\nFirst difference at offset 0" {
+		t.FailNow()
+	}
+}
@@ -0,0 +1,291 @@
+/*
+Copyright 2024-2025 Microbus LLC and various contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testarossa
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+)
+
+/*
+LeakCheck takes a snapshot of a kind of resource and returns a function that lists
+the resources that were acquired since the snapshot and are still held.
+*/
+type LeakCheck func() (leaked func() []string)
+
+/*
+NoLeaks takes a snapshot of resources and returns a function that fails the test
+if resources that were acquired after the snapshot are still held.
+Resources are given a grace period to be released, during which the checks are retried.
+If no checks are given, goroutines and open files are checked.
+The function can be deferred or registered as a cleanup function.
+
+	defer testarossa.NoLeaks(t)()
+	t.Cleanup(testarossa.NoLeaks(t, testarossa.OpenFiles(), testarossa.IdleConnections(client.Transport)))
+*/
+func NoLeaks(t TestingT, checks ...LeakCheck) func() {
+	if len(checks) == 0 {
+		checks = []LeakCheck{Goroutines(), OpenFiles()}
+	}
+	return checkAtEnd(t, "Leaked %d resource(s)", true, checks)
+}
+
+/*
+checkAtEnd takes the snapshots and returns a function that fails the test
+if any of the listers they return lists anything, under the heading.
+If retry is true, the listers are given the leak grace period to list nothing.
+The frames of the failure are those of the call to the exported function that called checkAtEnd.
+*/
+func checkAtEnd[S ~func() func() []string](t TestingT, heading string, retry bool, snapshots []S) func() {
+	frames := append(casePositions(t), stackFrames(nil)...)
+	// Create the state of the test before the check is registered as a cleanup function, so that it outlives the check
+	stateOf(t)
+	listers := make([]func() []string, len(snapshots))
+	for i, snapshot := range snapshots {
+		listers[i] = snapshot()
+	}
+	list := func() (found []any) {
+		for _, lister := range listers {
+			for _, item := range lister() {
+				found = append(found, Msg("%s", item))
+			}
+		}
+		return found
+	}
+	return func() {
+		countAssertion(t)
+		var found []any
+		if retry {
+			found = retryLeakCheck(list)
+		} else {
+			found = list()
+		}
+		if len(found) > 0 {
+			failAt(t, frames, append([]any{Msg(heading, len(found))}, found...))
+		}
+	}
+}
+
+// OpenFiles checks for file descriptors that are still open, including those of files, sockets and pipes.
+// Open file descriptors are listed in /proc/self/fd, so the check has no effect on operating systems other than Linux.
+func OpenFiles() LeakCheck {
+	return func() func() []string {
+		before := openFiles()
+		return func() (leaked []string) {
+			for fd, target := range openFiles() {
+				if before[fd] != target {
+					leaked = append(leaked, fmt.Sprintf("file descriptor %s -> %s", fd, target))
+				}
+			}
+			slices.Sort(leaked)
+			return leaked
+		}
+	}
+}
+
+// openFiles maps the open file descriptors of the process to their targets.
+func openFiles() map[string]string {
+	const fdDir = "/proc/self/fd"
+	entries, err := os.ReadDir(fdDir)
+	if err != nil {
+		return nil
+	}
+	self, _ := filepath.EvalSymlinks(fdDir)
+	files := map[string]string{}
+	for _, entry := range entries {
+		target, err := os.Readlink(filepath.Join(fdDir, entry.Name()))
+		if err != nil || target == self {
+			// Closed since listed, or the descriptor used to list the directory
+			continue
+		}
+		files[entry.Name()] = target
+	}
+	return files
+}
+
+/*
+TempFiles checks for files and directories that are still present in the directories.
+If no directories are given, the temp directory, as returned by os.TempDir, is checked.
+Files created there by other processes or by parallel tests are reported as leaked too,
+so prefer to give the directory that the code under test is configured to use.
+Directories created by t.TempDir are removed before cleanup functions registered earlier run,
+so NoLeaks should be registered as a cleanup function rather than deferred in tests that use them.
+*/
+func TempFiles(dirs ...string) LeakCheck {
+	if len(dirs) == 0 {
+		dirs = []string{os.TempDir()}
+	}
+	return func() func() []string {
+		before := make([][]string, len(dirs))
+		for i, dir := range dirs {
+			before[i] = tempFiles(dir)
+		}
+		return func() (leaked []string) {
+			for i, dir := range dirs {
+				for _, name := range tempFiles(dir) {
+					if !slices.Contains(before[i], name) {
+						leaked = append(leaked, "temp file "+filepath.Join(dir, name))
+					}
+				}
+			}
+			return leaked
+		}
+	}
+}
+
+// tempFiles returns the names of the entries of the directory.
+func tempFiles(dir string) []string {
+	entries, _ := os.ReadDir(dir)
+	names := make([]string, len(entries))
+	for i, entry := range entries {
+		names[i] = entry.Name()
+	}
+	return names
+}
+
+/*
+IdleConnections checks for connections of the HTTP transports that are still open,
+typically idle keep-alive connections or the connections of response bodies that were not closed.
+Only the transports given are checked, and transports that are not of type *http.Transport are not checked.
+
+The connections are tracked by wrapping the DialContext function of the transport,
+so only connections dialed after the transport is first given to IdleConnections are checked.
+The transport is modified, so it must be given to IdleConnections before it is used concurrently.
+A transport with a custom DialContext function does not attempt HTTP/2 by default,
+so ForceAttemptHTTP2 is set if the transport would have attempted HTTP/2 before it was modified.
+*/
+func IdleConnections(transports ...http.RoundTripper) LeakCheck {
+	var trackers []*connTracker
+	for _, transport := range transports {
+		if tr, ok := transport.(*http.Transport); ok && tr != nil {
+			trackers = append(trackers, trackConnections(tr))
+		}
+	}
+	return func() func() []string {
+		before := make([]int, len(trackers))
+		for i, tracker := range trackers {
+			before[i] = tracker.lastID()
+		}
+		return func() (leaked []string) {
+			for i, tracker := range trackers {
+				for _, addr := range tracker.openSince(before[i]) {
+					leaked = append(leaked, "open connection to "+addr)
+				}
+			}
+			slices.Sort(leaked)
+			return leaked
+		}
+	}
+}
+
+// connTrackers maps the instrumented HTTP transports to the trackers of their connections.
+var (
+	connTrackers    = map[*http.Transport]*connTracker{}
+	connTrackersMux sync.Mutex
+)
+
+// connTracker tracks the open connections dialed by an HTTP transport.
+type connTracker struct {
+	mux    sync.Mutex
+	nextID int
+	open   map[int]string
+}
+
+// trackConnections instruments the HTTP transport to track the connections it dials, if not already instrumented.
+func trackConnections(tr *http.Transport) *connTracker {
+	connTrackersMux.Lock()
+	defer connTrackersMux.Unlock()
+	if tracker, ok := connTrackers[tr]; ok {
+		return tracker
+	}
+	tracker := &connTracker{open: map[int]string{}}
+	// Any custom dial function or TLS config turns off the automatic attempt of HTTP/2
+	autoHTTP2 := tr.TLSClientConfig == nil && tr.Dial == nil && tr.DialContext == nil && tr.DialTLS == nil && tr.DialTLSContext == nil
+	tr.ForceAttemptHTTP2 = tr.ForceAttemptHTTP2 || autoHTTP2
+	dial := tr.DialContext
+	if dial == nil && tr.Dial != nil {
+		dialNoCtx := tr.Dial
+		dial = func(ctx context.Context, network string, addr string) (net.Conn, error) {
+			return dialNoCtx(network, addr)
+		}
+	}
+	if dial == nil {
+		dial = (&net.Dialer{}).DialContext
+	}
+	tr.DialContext = tracker.wrap(dial)
+	if tr.DialTLSContext != nil {
+		tr.DialTLSContext = tracker.wrap(tr.DialTLSContext)
+	}
+	connTrackers[tr] = tracker
+	return tracker
+}
+
+// wrap returns a dial function that tracks the connections dialed by the given dial function.
+func (ct *connTracker) wrap(dial func(ctx context.Context, network string, addr string) (net.Conn, error)) func(ctx context.Context, network string, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network string, addr string) (net.Conn, error) {
+		conn, err := dial(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+		ct.mux.Lock()
+		ct.nextID++
+		id := ct.nextID
+		ct.open[id] = addr
+		ct.mux.Unlock()
+		return &trackedConn{Conn: conn, tracker: ct, id: id}, nil
+	}
+}
+
+// lastID returns the ID of the last connection dialed.
+func (ct *connTracker) lastID() int {
+	ct.mux.Lock()
+	defer ct.mux.Unlock()
+	return ct.nextID
+}
+
+// openSince returns the addresses of the connections dialed after the connection with the given ID that are still open.
+func (ct *connTracker) openSince(id int) (addrs []string) {
+	ct.mux.Lock()
+	defer ct.mux.Unlock()
+	for connID, addr := range ct.open {
+		if connID > id {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs
+}
+
+// trackedConn is a connection that is untracked when closed.
+type trackedConn struct {
+	net.Conn
+	tracker *connTracker
+	id      int
+}
+
+// Close closes the connection and stops tracking it.
+func (c *trackedConn) Close() error {
+	c.tracker.mux.Lock()
+	delete(c.tracker.open, c.id)
+	c.tracker.mux.Unlock()
+	return c.Conn.Close()
+}
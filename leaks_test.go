@@ -0,0 +1,177 @@
+/*
+Copyright 2024-2025 Microbus LLC and various contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testarossa
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLeaks_OpenFiles(t *testing.T) {
+	if !GOOS("linux") {
+		t.Skip("Requires /proc/self/fd")
+	}
+	leakGracePeriod = 10 * time.Millisecond
+	defer func() { leakGracePeriod = time.Second }()
+
+	rt := &RecordingTestingT{}
+	check := NoLeaks(rt, OpenFiles())
+	f, err := os.Open("leaks_test.go")
+	if err != nil {
+		t.FailNow()
+	}
+	check()
+	if rt.Passed() || !strings.HasPrefix(rt.message, "Leaked 1 resource(s)\nfile descriptor ") || !strings.HasSuffix(rt.message, "leaks_test.go") {
+		t.Fatal(rt.message)
+	}
+	if !strings.HasSuffix(rt.file, "leaks_test.go") {
+		t.FailNow()
+	}
+
+	check = For(rt).NoLeaks(OpenFiles())
+	f.Close()
+	check()
+	if rt.Failed() {
+		t.FailNow()
+	}
+}
+
+func TestLeaks_TempFiles(t *testing.T) {
+	leakGracePeriod = 10 * time.Millisecond
+	defer func() { leakGracePeriod = time.Second }()
+
+	dir, err := os.MkdirTemp("", "testarossa-")
+	if err != nil {
+		t.FailNow()
+	}
+	defer os.RemoveAll(dir)
+
+	rt := &RecordingTestingT{}
+	check := NoLeaks(rt, TempFiles(dir))
+	f, err := os.CreateTemp(dir, "leak-")
+	if err != nil {
+		t.FailNow()
+	}
+	f.Close()
+	check()
+	if rt.Passed() || rt.message != "Leaked 1 resource(s)\ntemp file "+f.Name() {
+		t.Fatal(rt.message)
+	}
+
+	check = NoLeaks(rt, TempFiles(dir))
+	os.Remove(f.Name())
+	check()
+	if rt.Failed() {
+		t.FailNow()
+	}
+}
+
+func TestLeaks_IdleConnections(t *testing.T) {
+	leakGracePeriod = 10 * time.Millisecond
+	defer func() { leakGracePeriod = time.Second }()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+	transport := &http.Transport{}
+	client := &http.Client{Transport: transport}
+
+	rt := &RecordingTestingT{}
+	check := NoLeaks(rt, IdleConnections(transport))
+	res, err := client.Get(srv.URL)
+	if err != nil {
+		t.FailNow()
+	}
+	io.Copy(io.Discard, res.Body)
+	res.Body.Close()
+	check()
+	if rt.Passed() || rt.message != "Leaked 1 resource(s)\nopen connection to "+srv.Listener.Addr().String() {
+		t.Fatal(rt.message)
+	}
+
+	check = NoLeaks(rt, IdleConnections(transport))
+	transport.CloseIdleConnections()
+	check()
+	if rt.Failed() {
+		t.FailNow()
+	}
+
+	// Response body not closed
+	check = NoLeaks(rt, IdleConnections(transport))
+	res, err = client.Get(srv.URL)
+	if err != nil {
+		t.FailNow()
+	}
+	check()
+	if rt.Passed() {
+		t.FailNow()
+	}
+	res.Body.Close()
+	transport.CloseIdleConnections()
+
+	// Instrumented once
+	if trackConnections(transport) != trackConnections(transport) {
+		t.FailNow()
+	}
+
+	// HTTP/2 is attempted only if it would have been before
+	if !transport.ForceAttemptHTTP2 {
+		t.FailNow()
+	}
+	customTransport := &http.Transport{Dial: net.Dial}
+	IdleConnections(customTransport)
+	if customTransport.ForceAttemptHTTP2 {
+		t.FailNow()
+	}
+
+	// The default transport is not instrumented unless given
+	if tr, ok := http.DefaultTransport.(*http.Transport); ok && tr.DialContext != nil {
+		connTrackersMux.Lock()
+		_, instrumented := connTrackers[tr]
+		connTrackersMux.Unlock()
+		if instrumented {
+			t.FailNow()
+		}
+	}
+
+	// Not an *http.Transport
+	check = NoLeaks(rt, IdleConnections(http.NewFileTransport(http.Dir("."))))
+	check()
+	if rt.Failed() {
+		t.FailNow()
+	}
+}
+
+func TestLeaks_Default(t *testing.T) {
+	t.Run("cleanup", func(t *testing.T) {
+		t.Cleanup(NoLeaks(t))
+		done := make(chan struct{})
+		go func() {
+			close(done)
+		}()
+		<-done
+		runtime.Gosched()
+	})
+}
@@ -0,0 +1,318 @@
+/*
+Copyright 2024-2025 Microbus LLC and various contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testarossa
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+/*
+Logs is a slog.Handler that captures log records so that they can be asserted on.
+Records of all levels are captured.
+The attrs and groups of loggers derived with With and WithGroup are flattened into the attrs of the record,
+with the keys of grouped attrs qualified by the group names, separated by dots.
+
+	logs := testarossa.CaptureLogs(t)
+	svc := NewService(slog.New(logs))
+	svc.Do()
+	testarossa.LogContains(t, logs, slog.LevelInfo, "^Done$", "count", 2)
+	testarossa.NoLogsAbove(t, logs, slog.LevelWarn)
+*/
+type Logs struct {
+	capture *logCapture
+	attrs   []slog.Attr
+	groups  []string
+}
+
+// logCapture holds the records captured by a Logs and the handlers derived from it.
+type logCapture struct {
+	mux     sync.Mutex
+	records []slog.Record
+	logger  func(format string, args ...any)
+}
+
+/*
+CaptureLogs returns a slog.Handler that captures log records.
+If the test supports logging, as *testing.T does, the records are also logged by the test,
+so that they are shown if the test fails.
+*/
+func CaptureLogs(t TestingT) *Logs {
+	capture := &logCapture{}
+	if logger, ok := underlyingT(t).(interface {
+		Logf(format string, args ...any)
+	}); ok {
+		capture.logger = logger.Logf
+	}
+	if cleaner, ok := underlyingT(t).(interface{ Cleanup(func()) }); ok {
+		// Logging by the test after it completes panics
+		cleaner.Cleanup(func() {
+			capture.mux.Lock()
+			capture.logger = nil
+			capture.mux.Unlock()
+		})
+	}
+	return &Logs{capture: capture}
+}
+
+// Enabled returns true for all levels.
+func (logs *Logs) Enabled(ctx context.Context, level slog.Level) bool {
+	return true
+}
+
+// Handle captures the record.
+func (logs *Logs) Handle(ctx context.Context, r slog.Record) error {
+	flat := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	prefix := strings.Join(logs.groups, ".")
+	flat.AddAttrs(flattenAttrs("", logs.attrs)...)
+	var attrs []slog.Attr
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, a)
+		return true
+	})
+	flat.AddAttrs(flattenAttrs(prefix, attrs)...)
+	logs.capture.mux.Lock()
+	defer logs.capture.mux.Unlock()
+	logs.capture.records = append(logs.capture.records, flat)
+	if logs.capture.logger != nil {
+		logs.capture.logger("%s", formatRecord(flat))
+	}
+	return nil
+}
+
+// WithAttrs returns a handler that adds the attrs to the records it captures.
+func (logs *Logs) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &Logs{
+		capture: logs.capture,
+		attrs:   append(slices.Clip(logs.attrs), flattenAttrs(strings.Join(logs.groups, "."), attrs)...),
+		groups:  logs.groups,
+	}
+}
+
+// WithGroup returns a handler that qualifies the keys of the attrs of the records it captures with the group name.
+func (logs *Logs) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return logs
+	}
+	return &Logs{
+		capture: logs.capture,
+		attrs:   logs.attrs,
+		groups:  append(slices.Clip(logs.groups), name),
+	}
+}
+
+// Records returns the captured records.
+func (logs *Logs) Records() []slog.Record {
+	logs.capture.mux.Lock()
+	defer logs.capture.mux.Unlock()
+	return slices.Clone(logs.capture.records)
+}
+
+// Reset discards the captured records.
+func (logs *Logs) Reset() {
+	logs.capture.mux.Lock()
+	logs.capture.records = nil
+	logs.capture.mux.Unlock()
+}
+
+// flattenAttrs resolves the attrs and replaces group attrs with their members,
+// with keys qualified by the prefix and the group names, separated by dots.
+func flattenAttrs(prefix string, attrs []slog.Attr) (flat []slog.Attr) {
+	for _, a := range attrs {
+		a.Value = a.Value.Resolve()
+		if a.Equal(slog.Attr{}) {
+			continue
+		}
+		key := a.Key
+		if prefix != "" && key != "" {
+			key = prefix + "." + key
+		} else if key == "" {
+			key = prefix
+		}
+		if a.Value.Kind() == slog.KindGroup {
+			flat = append(flat, flattenAttrs(key, a.Value.Group())...)
+			continue
+		}
+		flat = append(flat, slog.Attr{Key: key, Value: a.Value})
+	}
+	return flat
+}
+
+// formatRecord formats a record as text, without its time.
+func formatRecord(r slog.Record) string {
+	var buf bytes.Buffer
+	handler := slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		Level: slog.Level(-1 << 10),
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+	handler.Handle(context.Background(), r)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// recordsArgs returns the args of FailIf that list the captured records.
+func recordsArgs(records []slog.Record) []any {
+	if len(records) == 0 {
+		return []any{Msg("No logs were captured")}
+	}
+	args := []any{Msg("Captured logs:")}
+	for _, r := range records {
+		args = append(args, Msg("    %s", formatRecord(r)))
+	}
+	return args
+}
+
+// recordMatches indicates if the record is at the level, its message matches the regular expression,
+// and it has all of the attrs.
+func recordMatches(r slog.Record, level slog.Level, re *regexp.Regexp, attrs []slog.Attr) bool {
+	if r.Level != level || !re.MatchString(r.Message) {
+		return false
+	}
+	for _, want := range attrs {
+		found := false
+		r.Attrs(func(a slog.Attr) bool {
+			found = a.Key == want.Key && (a.Value.Equal(want.Value) || a.Value.String() == want.Value.String())
+			return !found
+		})
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// attrsOf converts alternating keys and values, or slog.Attrs, to flattened attrs, the same way slog.Logger does.
+func attrsOf(keyValues []any) []slog.Attr {
+	r := slog.NewRecord(time.Time{}, 0, "", 0)
+	r.Add(keyValues...)
+	var attrs []slog.Attr
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, a)
+		return true
+	})
+	return flattenAttrs("", attrs)
+}
+
+/*
+LogContains fails the test if no captured record is at the level, with a message that matches the regular expression
+and with all of the attrs.
+The attrs are alternating keys and values, or slog.Attrs, the same as the args of slog.Logger.
+Keys of grouped attrs are qualified by the group names, separated by dots.
+
+	testarossa.LogContains(t, logs, slog.LevelError, "^Failed to connect", "host", "db1", "attempt", 3)
+*/
+func LogContains(t TestingT, logs *Logs, level slog.Level, msgRegexp string, attrs ...any) bool {
+	countAssertion(t)
+	re, err := regexp.Compile(msgRegexp)
+	if err != nil {
+		fail(
+			t,
+			true,
+			"Invalid regular expression '%s': %s", msgRegexp, err.Error(),
+		)
+		return false
+	}
+	want := attrsOf(attrs)
+	records := logs.Records()
+	for _, r := range records {
+		if recordMatches(r, level, re, want) {
+			return true
+		}
+	}
+	msgArgs := []any{"Expected a log at level %s with message matching '%v'", level, v(msgRegexp)}
+	if len(want) > 0 {
+		msgArgs = append(msgArgs, Msg("With attrs %s", formatAttrs(want)))
+	}
+	fail(
+		t,
+		true,
+		append(msgArgs, recordsArgs(records)...)...,
+	)
+	return false
+}
+
+// NoLogsAbove fails the test if any captured record is at a level above the level.
+func NoLogsAbove(t TestingT, logs *Logs, level slog.Level, args ...any) bool {
+	countAssertion(t)
+	records := logs.Records()
+	if !slices.ContainsFunc(records, func(r slog.Record) bool { return r.Level > level }) {
+		return true
+	}
+	msgArgs := []any{Msg("Expected no logs above level %s", level)}
+	msgArgs = append(msgArgs, recordsArgs(records)...)
+	fail(
+		t,
+		true,
+		append(msgArgs, args...)...,
+	)
+	return false
+}
+
+// LogCount fails the test if the number of captured records at the level, with a message that matches the regular expression,
+// is not as expected.
+// An empty regular expression matches all messages.
+func LogCount(t TestingT, logs *Logs, level slog.Level, msgRegexp string, expected int, args ...any) bool {
+	countAssertion(t)
+	re, err := regexp.Compile(msgRegexp)
+	if err != nil {
+		msgArgs := []any{"Invalid regular expression '%s': %s", msgRegexp, err.Error()}
+		fail(
+			t,
+			true,
+			append(msgArgs, args...)...,
+		)
+		return false
+	}
+	records := logs.Records()
+	count := 0
+	for _, r := range records {
+		if recordMatches(r, level, re, nil) {
+			count++
+		}
+	}
+	if count == expected {
+		return true
+	}
+	msgArgs := []any{Msg("Expected %d log(s) at level %s with message matching '%s', actual %d", expected, level, msgRegexp, count)}
+	msgArgs = append(msgArgs, recordsArgs(records)...)
+	fail(
+		t,
+		true,
+		append(msgArgs, args...)...,
+	)
+	return false
+}
+
+// formatAttrs formats the attrs as space-separated key=value pairs.
+func formatAttrs(attrs []slog.Attr) string {
+	parts := make([]string, len(attrs))
+	for i, a := range attrs {
+		parts[i] = fmt.Sprintf("%s=%s", a.Key, a.Value)
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2024-2025 Microbus LLC and various contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testarossa
+
+import (
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestLogs_Capture(t *testing.T) {
+	logs := CaptureLogs(t)
+	logger := slog.New(logs)
+	logger.Info("Started", "port", 8080)
+	logger.With("tenant", "acme").WithGroup("req").Warn("Slow request", "ms", 1200, slog.Group("user", "id", 7))
+	logger.Debug("Done")
+
+	records := logs.Records()
+	if len(records) != 3 {
+		t.FailNow()
+	}
+	if formatRecord(records[1]) != `level=WARN msg="Slow request" tenant=acme req.ms=1200 req.user.id=7` {
+		t.Fatal(formatRecord(records[1]))
+	}
+	logs.Reset()
+	if len(logs.Records()) != 0 {
+		t.FailNow()
+	}
+}
+
+func TestLogs_LogContains(t *testing.T) {
+	mt := &MockTestingT{}
+	logs := CaptureLogs(mt)
+	logger := slog.New(logs)
+	logger.Info("Started", "port", 8080)
+	logger.WithGroup("req").Warn("Slow request", "ms", 1200)
+
+	if !LogContains(mt, logs, slog.LevelInfo, "^Start", "port", 8080) || mt.Failed() {
+		t.FailNow()
+	}
+	if !LogContains(mt, logs, slog.LevelWarn, "", slog.Int("req.ms", 1200)) || mt.Failed() {
+		t.FailNow()
+	}
+	if !For(mt).LogContains(logs, slog.LevelInfo, "Started") || mt.Failed() {
+		t.FailNow()
+	}
+	if LogContains(mt, logs, slog.LevelInfo, "^Start", "port", 8081) || mt.Passed() {
+		t.FailNow()
+	}
+	if LogContains(mt, logs, slog.LevelError, "Started") || mt.Passed() {
+		t.FailNow()
+	}
+	if LogContains(mt, logs, slog.LevelInfo, "[") || mt.Passed() {
+		t.FailNow()
+	}
+
+	rt := &RecordingTestingT{}
+	LogContains(rt, logs, slog.LevelInfo, "^Start", "port", 8081)
+	expected := "Expected a log at level INFO with message matching '^Start'\n" +
+		"With attrs port=8081\n" +
+		"Captured logs:\n" +
+		"    level=INFO msg=Started port=8080\n" +
+		"    level=WARN msg=\"Slow request\" req.ms=1200"
+	if rt.message != expected {
+		t.Fatal(rt.message)
+	}
+}
+
+func TestLogs_NoLogsAbove(t *testing.T) {
+	mt := &MockTestingT{}
+	logs := CaptureLogs(mt)
+	logger := slog.New(logs)
+	logger.Warn("Careful")
+
+	if !NoLogsAbove(mt, logs, slog.LevelWarn) || mt.Failed() {
+		t.FailNow()
+	}
+	if NoLogsAbove(mt, logs, slog.LevelInfo) || mt.Passed() {
+		t.FailNow()
+	}
+	logger.Error("Boom")
+	if For(mt).NoLogsAbove(logs, slog.LevelWarn) || mt.Passed() {
+		t.FailNow()
+	}
+
+	rt := &RecordingTestingT{}
+	NoLogsAbove(rt, CaptureLogs(rt), slog.LevelDebug)
+	if rt.Failed() {
+		t.FailNow()
+	}
+}
+
+func TestLogs_LogCount(t *testing.T) {
+	mt := &MockTestingT{}
+	logs := CaptureLogs(mt)
+	logger := slog.New(logs)
+	logger.Info("Retry", "attempt", 1)
+	logger.Info("Retry", "attempt", 2)
+	logger.Info("Done")
+
+	if !LogCount(mt, logs, slog.LevelInfo, "^Retry$", 2) || mt.Failed() {
+		t.FailNow()
+	}
+	if !For(mt).LogCount(logs, slog.LevelInfo, "", 3) || mt.Failed() {
+		t.FailNow()
+	}
+	if LogCount(mt, logs, slog.LevelWarn, "", 1) || mt.Passed() {
+		t.FailNow()
+	}
+
+	rt := &RecordingTestingT{}
+	LogCount(rt, CaptureLogs(rt), slog.LevelInfo, "", 1, "Custom")
+	if rt.message != "Expected 1 log(s) at level INFO with message matching '', actual 0\nNo logs were captured\nCustom" {
+		t.Fatal(rt.message)
+	}
+	if !strings.HasSuffix(rt.file, "logs_test.go") {
+		t.FailNow()
+	}
+}
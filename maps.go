@@ -0,0 +1,265 @@
+/*
+Copyright 2024-2025 Microbus LLC and various contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testarossa
+
+import (
+	"net/http"
+	"net/textproto"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+/*
+ContainsEntry fails the test if the map does not contain the key, or if the value at that key is not equal to the expected value.
+Besides regular maps, it supports http.Header, whose keys are canonicalized, url.Values and *sync.Map.
+A string value is compared against the first value of an http.Header or url.Values key.
+
+	ContainsEntry(t, headers, "Content-Type", "text/html")
+*/
+func ContainsEntry(t TestingT, m any, key any, value any, args ...any) bool {
+	countAssertion(t)
+	entries, ok := entriesOf(t, m, args)
+	if !ok {
+		return false
+	}
+	actual, found := entries.lookup(key)
+	if !found {
+		msgArgs := []any{"Expected '%v' to contain key '%v'", v(entries), v(key)}
+		fail(
+			t,
+			true,
+			append(msgArgs, args...)...,
+		)
+		return false
+	}
+	if entryValueEqual(value, actual) {
+		return true
+	}
+	_, stringToStrings := value.(string)
+	if _, ok := actual.([]string); !ok {
+		stringToStrings = false
+	}
+	var msgArgs []any
+	if !isNil(value) && !isNil(actual) && !stringToStrings && reflect.TypeOf(value) != reflect.TypeOf(actual) {
+		msgArgs = []any{"Expected type %v at key '%v', actual type %v", reflect.TypeOf(value), v(key), reflect.TypeOf(actual)}
+	} else {
+		expectedArg, actualArg, offsetArg := diffArgs(value, actual)
+		msgArgs = []any{"Expected '%v' at key '%v', actual '%v'", expectedArg, v(key), actualArg, offsetArg}
+	}
+	fail(
+		t,
+		true,
+		append(msgArgs, args...)...,
+	)
+	return false
+}
+
+// MapSubset fails the test if any of the keys of the subset map is missing from the superset map,
+// or if the values at that key are not equal.
+// Besides regular maps, it supports http.Header, whose keys are canonicalized, url.Values and *sync.Map.
+func MapSubset(t TestingT, superset any, subset any, args ...any) bool {
+	countAssertion(t)
+	supersetEntries, ok := entriesOf(t, superset, args)
+	if !ok {
+		return false
+	}
+	subsetEntries, ok := entriesOf(t, subset, args)
+	if !ok {
+		return false
+	}
+	var missing []any
+	var differing []any
+	for _, entry := range subsetEntries.sorted() {
+		actual, found := supersetEntries.lookup(entry.key)
+		if !found {
+			missing = append(missing, entry.key)
+		} else if !entryValueEqual(entry.value, actual) {
+			expectedArg, actualArg, offsetArg := diffArgs(entry.value, actual)
+			differing = append(differing, "Key '%v': expected '%v', actual '%v'", v(entry.key), expectedArg, actualArg, offsetArg)
+		}
+	}
+	if len(missing) == 0 && len(differing) == 0 {
+		return true
+	}
+	msgArgs := []any{"Expected '%v' to be a subset of '%v'", v(subsetEntries), v(supersetEntries)}
+	if len(missing) > 0 {
+		msgArgs = append(msgArgs, "Missing keys '%v'", v(missing))
+	}
+	msgArgs = append(msgArgs, differing...)
+	fail(
+		t,
+		true,
+		append(msgArgs, args...)...,
+	)
+	return false
+}
+
+// KeysEqual fails the test if the keys of the map are not exactly the given keys, irrespective of their order.
+// Besides regular maps, it supports http.Header, whose keys are canonicalized, url.Values and *sync.Map.
+func KeysEqual(t TestingT, m any, keys ...any) bool {
+	countAssertion(t)
+	entries, ok := entriesOf(t, m, nil)
+	if !ok {
+		return false
+	}
+	var missing []any
+	expectedKeys := &mapEntries{header: entries.header}
+	for _, key := range keys {
+		expectedKeys.entries = append(expectedKeys.entries, mapEntry{key: key})
+		if _, found := entries.lookup(key); !found {
+			missing = append(missing, key)
+		}
+	}
+	var extra []any
+	for _, entry := range entries.sorted() {
+		if _, found := expectedKeys.lookup(entry.key); !found {
+			extra = append(extra, entry.key)
+		}
+	}
+	if len(missing) == 0 && len(extra) == 0 {
+		return true
+	}
+	expectedArg, actualArg, offsetArg := diffArgs(keys, entries.keys())
+	msgArgs := []any{"Expected keys '%v', actual '%v'", expectedArg, actualArg, offsetArg}
+	if len(missing) > 0 {
+		msgArgs = append(msgArgs, "Missing keys '%v'", v(missing))
+	}
+	if len(extra) > 0 {
+		msgArgs = append(msgArgs, "Extra keys '%v'", v(extra))
+	}
+	fail(
+		t,
+		true,
+		msgArgs...,
+	)
+	return false
+}
+
+// mapEntry is a single key/value pair of a map.
+type mapEntry struct {
+	key   any
+	value any
+}
+
+// mapEntries is a uniform view of the entries of the various map types.
+type mapEntries struct {
+	entries []mapEntry
+	header  bool
+}
+
+// entriesOf returns the entries of a map, failing the test if the object is not a supported map type.
+// Nil is treated as an empty map.
+func entriesOf(t TestingT, m any, args []any) (entries *mapEntries, ok bool) {
+	entries = &mapEntries{}
+	if isNil(m) {
+		return entries, true
+	}
+	if syncMap, ok := m.(*sync.Map); ok {
+		syncMap.Range(func(key, value any) bool {
+			entries.entries = append(entries.entries, mapEntry{key: key, value: value})
+			return true
+		})
+		return entries, true
+	}
+	_, entries.header = m.(http.Header)
+	mValue := reflect.ValueOf(m)
+	if mValue.Kind() != reflect.Map {
+		msgArgs := []any{"Type %v is not a map", mValue.Type()}
+		fail(
+			t,
+			true,
+			append(msgArgs, args...)...,
+		)
+		return nil, false
+	}
+	mapIter := mValue.MapRange()
+	for mapIter.Next() {
+		entries.entries = append(entries.entries, mapEntry{key: mapIter.Key().Interface(), value: mapIter.Value().Interface()})
+	}
+	return entries, true
+}
+
+// lookup returns the value of the key.
+// Keys of an http.Header are compared in their canonical form.
+func (me *mapEntries) lookup(key any) (value any, found bool) {
+	key = me.canonicalKey(key)
+	for _, entry := range me.entries {
+		if reflect.DeepEqual(me.canonicalKey(entry.key), key) {
+			return entry.value, true
+		}
+	}
+	return nil, false
+}
+
+// canonicalKey canonicalizes the key if the entries are of an http.Header.
+func (me *mapEntries) canonicalKey(key any) any {
+	if s, ok := key.(string); ok && me.header {
+		return textproto.CanonicalMIMEHeaderKey(s)
+	}
+	return key
+}
+
+// sorted returns the entries sorted by the string representation of their keys.
+func (me *mapEntries) sorted() []mapEntry {
+	sorted := make([]mapEntry, len(me.entries))
+	copy(sorted, me.entries)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return render(sorted[i].key) < render(sorted[j].key)
+	})
+	return sorted
+}
+
+// keys returns the keys sorted by their string representation.
+func (me *mapEntries) keys() []any {
+	keys := []any{}
+	for _, entry := range me.sorted() {
+		keys = append(keys, entry.key)
+	}
+	return keys
+}
+
+// String renders the entries like fmt renders a map, which is also useful for *sync.Map.
+func (me *mapEntries) String() string {
+	var sb strings.Builder
+	sb.WriteString("map[")
+	for i, entry := range me.sorted() {
+		if i > 0 {
+			sb.WriteString(" ")
+		}
+		sb.WriteString(render(entry.key))
+		sb.WriteString(":")
+		sb.WriteString(render(entry.value))
+	}
+	sb.WriteString("]")
+	return sb.String()
+}
+
+// entryValueEqual compares an expected value to the actual value of a map entry.
+// A string is compared against the first value of a []string, as is the case for http.Header and url.Values.
+func entryValueEqual(expected any, actual any) bool {
+	if s, ok := expected.(string); ok {
+		if values, ok := actual.([]string); ok {
+			return len(values) > 0 && values[0] == s
+		}
+	}
+	if isNil(expected) && isNil(actual) {
+		return true
+	}
+	return reflect.DeepEqual(expected, actual)
+}
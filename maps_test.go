@@ -0,0 +1,157 @@
+/*
+Copyright 2024-2025 Microbus LLC and various contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testarossa
+
+import (
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+)
+
+func Test_ContainsEntry(t *testing.T) {
+	mt := &MockTestingT{}
+
+	m := map[string]int{"a": 1, "b": 2}
+	if !ContainsEntry(mt, m, "a", 1) || mt.Failed() {
+		t.FailNow()
+	}
+	if ContainsEntry(mt, m, "a", 2) || mt.Passed() {
+		t.FailNow()
+	}
+	if ContainsEntry(mt, m, "c", 1) || mt.Passed() {
+		t.FailNow()
+	}
+	if ContainsEntry(mt, m, "a", int64(1)) || mt.Passed() {
+		t.FailNow()
+	}
+	if ContainsEntry(mt, nil, "a", 1) || mt.Passed() {
+		t.FailNow()
+	}
+	if ContainsEntry(mt, []int{1}, 0, 1) || mt.Passed() {
+		t.FailNow()
+	}
+
+	// http.Header
+	h := http.Header{}
+	h.Set("Content-Type", "text/html")
+	h.Add("Accept", "text/html")
+	h.Add("Accept", "application/json")
+	if !ContainsEntry(mt, h, "content-type", "text/html") || mt.Failed() {
+		t.FailNow()
+	}
+	if !ContainsEntry(mt, h, "ACCEPT", []string{"text/html", "application/json"}) || mt.Failed() {
+		t.FailNow()
+	}
+	if ContainsEntry(mt, h, "Content-Type", "text/plain") || mt.Passed() {
+		t.FailNow()
+	}
+
+	// url.Values
+	q := url.Values{}
+	q.Set("id", "123")
+	if !ContainsEntry(mt, q, "id", "123") || mt.Failed() {
+		t.FailNow()
+	}
+	if ContainsEntry(mt, q, "ID", "123") || mt.Passed() {
+		t.FailNow()
+	}
+
+	// sync.Map
+	var sm sync.Map
+	sm.Store("x", 1)
+	if !ContainsEntry(mt, &sm, "x", 1) || mt.Failed() {
+		t.FailNow()
+	}
+	if ContainsEntry(mt, &sm, "y", 1) || mt.Passed() {
+		t.FailNow()
+	}
+}
+
+func Test_MapSubset(t *testing.T) {
+	mt := &MockTestingT{}
+
+	superset := map[string]int{"a": 1, "b": 2, "c": 3}
+	if !MapSubset(mt, superset, map[string]int{"a": 1, "c": 3}) || mt.Failed() {
+		t.FailNow()
+	}
+	if !MapSubset(mt, superset, map[string]int{}) || mt.Failed() {
+		t.FailNow()
+	}
+	if !MapSubset(mt, superset, nil) || mt.Failed() {
+		t.FailNow()
+	}
+	if MapSubset(mt, superset, map[string]int{"a": 1, "d": 4}) || mt.Passed() {
+		t.FailNow()
+	}
+	if MapSubset(mt, superset, map[string]int{"a": 2}) || mt.Passed() {
+		t.FailNow()
+	}
+
+	h := http.Header{}
+	h.Set("Content-Type", "text/html")
+	h.Set("X-Request-Id", "123")
+	if !MapSubset(mt, h, http.Header{"x-request-id": {"123"}}) || mt.Failed() {
+		t.FailNow()
+	}
+
+	var sm sync.Map
+	sm.Store("a", 1)
+	sm.Store("b", 2)
+	if !MapSubset(mt, &sm, map[string]int{"b": 2}) || mt.Failed() {
+		t.FailNow()
+	}
+	if !MapSubset(mt, superset, &sm) || mt.Failed() {
+		t.FailNow()
+	}
+}
+
+func Test_KeysEqual(t *testing.T) {
+	mt := &MockTestingT{}
+
+	m := map[string]int{"a": 1, "b": 2}
+	if !KeysEqual(mt, m, "b", "a") || mt.Failed() {
+		t.FailNow()
+	}
+	if KeysEqual(mt, m, "a") || mt.Passed() {
+		t.FailNow()
+	}
+	if KeysEqual(mt, m, "a", "b", "c") || mt.Passed() {
+		t.FailNow()
+	}
+	if !KeysEqual(mt, map[string]int{}) || mt.Failed() {
+		t.FailNow()
+	}
+	if !KeysEqual(mt, nil) || mt.Failed() {
+		t.FailNow()
+	}
+
+	h := http.Header{}
+	h.Set("Content-Type", "text/html")
+	if !KeysEqual(mt, h, "content-type") || mt.Failed() {
+		t.FailNow()
+	}
+
+	var sm sync.Map
+	sm.Store(1, "x")
+	if !KeysEqual(mt, &sm, 1) || mt.Failed() {
+		t.FailNow()
+	}
+	if KeysEqual(mt, &sm, 2) || mt.Passed() {
+		t.FailNow()
+	}
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2024-2025 Microbus LLC and various contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testarossa
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+)
+
+const (
+	// defaultMaxLen is the default limit on the length, in runes, of values shown in failure messages.
+	defaultMaxLen = 1024
+	// maxLenEnvVar is the environment variable that overrides the default limit on the length of values.
+	maxLenEnvVar = "TESTAROSSA_MAX_LEN"
+)
+
+/*
+Option customizes the formatting of failure messages.
+Options can be passed in the args of any assertion to apply to that assertion only,
+or to WithOptions to apply to all assertions of an Asserter.
+
+	testarossa.Equal(t, expected, actual, testarossa.MaxLen(0))
+	tt := testarossa.For(t).WithOptions(testarossa.MaxLen(4096))
+*/
+type Option func(opts *options)
+
+// options are the resolved settings for formatting a failure message.
+type options struct {
+	maxLen int
+	escape bool
+}
+
+/*
+MaxLen limits the length, in runes, of values shown in failure messages.
+A limit of 0 or less means unlimited.
+The default limit is 1024 runes, unless overridden by the TESTAROSSA_MAX_LEN environment variable.
+*/
+func MaxLen(n int) Option {
+	return func(opts *options) {
+		opts.maxLen = n
+	}
+}
+
+/*
+EscapeAlways shows values in failure messages in their escaped form, with codepoints for invisible and non-ASCII runes.
+Without it, the escaped form is used only for expected and actual values that look alike, for example if they differ only in whitespace.
+*/
+func EscapeAlways() Option {
+	return func(opts *options) {
+		opts.escape = true
+	}
+}
+
+// defaultOptions returns the default options, as overridden by environment variables.
+func defaultOptions() options {
+	opts := options{
+		maxLen: defaultMaxLen,
+	}
+	if s := os.Getenv(maxLenEnvVar); s != "" {
+		if n, err := strconv.Atoi(s); err == nil {
+			opts.maxLen = n
+		}
+	}
+	return opts
+}
+
+// optionsOf resolves the options of an assertion.
+// The options of the Asserter, if any, override the defaults, and the options in the args override both.
+func optionsOf(t TestingT, args []any) options {
+	opts := defaultOptions()
+	if tt, ok := t.(*Asserter); ok {
+		tt.applyOptions(&opts)
+	}
+	for _, arg := range args {
+		if opt, ok := arg.(Option); ok {
+			opt(&opts)
+		}
+	}
+	return opts
+}
+
+// diffValue is the string representation of an expected or actual value shown in a failure message.
+// It is truncated around its first difference from the other value.
+type diffValue struct {
+	s     string
+	other string
+}
+
+// diffOffset reports the offset of the first difference between the expected and actual values,
+// if either is truncated in the failure message.
+// It also carries the expected and actual values to the failure hooks.
+type diffOffset struct {
+	expected string
+	actual   string
+}
+
+// render renders a value, diffValue or diffOffset as per the options.
+// Other args are returned as is.
+func (opts options) render(arg any) any {
+	switch arg := arg.(type) {
+	case value:
+		s := string(arg)
+		if opts.escape {
+			s = escape(s, false)
+		}
+		return truncate(s, opts.maxLen)
+	case diffValue:
+		s, _, _, _ := opts.formatPair(arg.s, arg.other)
+		return s
+	case diffOffset:
+		_, _, offset, truncated := opts.formatPair(arg.expected, arg.actual)
+		if truncated && offset >= 0 {
+			return fmt.Sprintf("First difference at offset %d", offset)
+		}
+		return ""
+	}
+	return arg
+}
+
+// formatPair formats the string representations of an expected and an actual value to be shown side by side.
+// Both are escaped if they look alike, or if the options say so, and truncated around their first difference.
+// It also returns the rune offset of the first difference, or -1 if there is none, and whether either was truncated.
+func (opts options) formatPair(expected string, actual string) (expectedStr string, actualStr string, offset int, truncated bool) {
+	offset = firstDifference([]rune(expected), []rune(actual))
+	if opts.escape || looksAlike(expected, actual) {
+		ascii := confusable(expected, actual)
+		expected, actual = escape(expected, ascii), escape(actual, ascii)
+	}
+	expectedStr, _, expectedCut := truncateAround(expected, actual, opts.maxLen)
+	actualStr, _, actualCut := truncateAround(actual, expected, opts.maxLen)
+	return expectedStr, actualStr, offset, expectedCut || actualCut
+}
+
+// truncate keeps the first maxLen runes of s and appends an ellipsis if anything was cut.
+// A limit of 0 or less means unlimited.
+func truncate(s string, maxLen int) string {
+	if maxLen <= 0 || len(s) <= maxLen {
+		return s
+	}
+	rs := []rune(s)
+	if len(rs) <= maxLen {
+		return s
+	}
+	return string(rs[:maxLen]) + "\u2026"
+}
+
+// truncateAround keeps a window of maxLen runes of s around its first difference from the other string,
+// and marks any cut on either side with an ellipsis.
+// The window is aligned the same for both strings, so that they line up when shown one after the other.
+// It returns the rune offset of the first difference, or -1 if the strings are equal,
+// and whether s was truncated.
+// A limit of 0 or less means unlimited.
+func truncateAround(s string, other string, maxLen int) (truncated string, offset int, cut bool) {
+	rs := []rune(s)
+	ro := []rune(other)
+	offset = firstDifference(rs, ro)
+	if maxLen <= 0 || len(rs) <= maxLen && len(ro) <= maxLen {
+		return s, offset, false
+	}
+	start := 0
+	if offset > 0 {
+		// Center the window on the difference, but do not waste it beyond the end of the longer string
+		start = max(0, min(offset-maxLen/2, max(len(rs), len(ro))-maxLen))
+	}
+	end := min(len(rs), start+maxLen)
+	if start >= end {
+		start = max(0, end-maxLen)
+	}
+	if start == 0 && end == len(rs) {
+		return s, offset, false
+	}
+	truncated = string(rs[start:end])
+	if start > 0 {
+		truncated = "\u2026" + truncated
+	}
+	if end < len(rs) {
+		truncated += "\u2026"
+	}
+	return truncated, offset, true
+}
+
+// firstDifference returns the offset of the first rune that differs between the two strings, or -1 if they are equal.
+func firstDifference(rs []rune, ro []rune) int {
+	offset := 0
+	for offset < len(rs) && offset < len(ro) && rs[offset] == ro[offset] {
+		offset++
+	}
+	if offset == len(rs) && offset == len(ro) {
+		return -1
+	}
+	return offset
+}
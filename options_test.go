@@ -0,0 +1,189 @@
+/*
+Copyright 2024-2025 Microbus LLC and various contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testarossa
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_Truncate(t *testing.T) {
+	if truncate("hello", 5) != "hello" {
+		t.FailNow()
+	}
+	if truncate("hello", 4) != "hell…" {
+		t.FailNow()
+	}
+	if truncate("héllo", 2) != "hé…" {
+		t.FailNow()
+	}
+	if truncate("hello", 0) != "hello" {
+		t.FailNow()
+	}
+}
+
+func Test_TruncateAround(t *testing.T) {
+	expected := strings.Repeat("a", 100) + "X" + strings.Repeat("b", 100)
+	actual := strings.Repeat("a", 100) + "Y" + strings.Repeat("b", 100)
+
+	s, offset, cut := truncateAround(expected, actual, 20)
+	if s != "…aaaaaaaaaaXbbbbbbbbb…" || offset != 100 || !cut {
+		t.Fatal(s, offset, cut)
+	}
+	s, _, _ = truncateAround(actual, expected, 20)
+	if s != "…aaaaaaaaaaYbbbbbbbbb…" {
+		t.Fatal(s)
+	}
+
+	// Difference near the beginning
+	s, offset, cut = truncateAround("X"+expected, "Y"+expected, 10)
+	if s != "Xaaaaaaaaa…" || offset != 0 || !cut {
+		t.Fatal(s, offset, cut)
+	}
+
+	// Difference at the end of the shorter string
+	s, offset, cut = truncateAround("abcdefghij", "abcdefghijklmnop", 8)
+	if s != "…ghij" || offset != 10 || !cut {
+		t.Fatal(s, offset, cut)
+	}
+	s, _, _ = truncateAround("abcdefghijklmnop", "abcdefghij", 8)
+	if s != "…ghijklmn…" {
+		t.Fatal(s)
+	}
+
+	// Short or unlimited
+	s, offset, cut = truncateAround("abc", "abd", 10)
+	if s != "abc" || offset != 2 || cut {
+		t.Fatal(s, offset, cut)
+	}
+	s, _, cut = truncateAround(expected, actual, 0)
+	if s != expected || cut {
+		t.FailNow()
+	}
+	_, offset, _ = truncateAround(expected, expected, 10)
+	if offset != -1 {
+		t.FailNow()
+	}
+}
+
+func Test_MaxLen(t *testing.T) {
+	rt := &RecordingTestingT{}
+
+	expected := strings.Repeat("x", 5000) + "expected" + strings.Repeat("z", 5000)
+	actual := strings.Repeat("x", 5000) + "actual" + strings.Repeat("z", 5000)
+
+	// Default
+	if Equal(rt, expected, actual) || rt.Passed() {
+		t.FailNow()
+	}
+	lines := strings.Split(rt.message, "\n")
+	if len(lines) != 2 || lines[1] != "First difference at offset 5000" {
+		t.FailNow()
+	}
+	if !strings.HasPrefix(lines[0], "Expected '…xxx") || !strings.Contains(lines[0], "xexpectedz") || !strings.Contains(lines[0], "zzz…', actual '…xxx") {
+		t.FailNow()
+	}
+	if !strings.Contains(lines[0], "xactualz") || !strings.HasSuffix(lines[0], "zzz…'") {
+		t.FailNow()
+	}
+	if len([]rune(lines[0])) > 2*(defaultMaxLen+2)+len("Expected '', actual ''") {
+		t.FailNow()
+	}
+
+	// Per call
+	if Equal(rt, expected, actual, MaxLen(0), "Unlimited") || rt.Passed() {
+		t.FailNow()
+	}
+	if rt.message != "Expected '"+expected+"', actual '"+actual+"'\nUnlimited" {
+		t.FailNow()
+	}
+	if Equal(rt, expected, actual, MaxLen(20)) || rt.Passed() {
+		t.FailNow()
+	}
+	if rt.message != "Expected '…xxxxxxxxxxexpectedzz…', actual '…xxxxxxxxxxactualzzzz…'\nFirst difference at offset 5000" {
+		t.FailNow()
+	}
+
+	// Per Asserter, overridden per call
+	tt := For(rt).WithOptions(MaxLen(20))
+	if tt.Contains(expected, "nothing") || rt.Passed() {
+		t.FailNow()
+	}
+	if rt.message != "Expected 'xxxxxxxxxxxxxxxxxxxx…' to contain 'nothing'" {
+		t.FailNow()
+	}
+	if tt.WithOptions(MaxLen(5)).Contains(expected, "nothing") || rt.Passed() {
+		t.FailNow()
+	}
+	if rt.message != "Expected 'xxxxx…' to contain 'nothi…'" {
+		t.FailNow()
+	}
+	if tt.Contains(expected, "nothing", MaxLen(3)) || rt.Passed() {
+		t.FailNow()
+	}
+	if rt.message != "Expected 'xxx…' to contain 'not…'" {
+		t.FailNow()
+	}
+
+	// Environment variable
+	t.Setenv(maxLenEnvVar, "10")
+	if Contains(rt, expected, "nothing") || rt.Passed() {
+		t.FailNow()
+	}
+	if rt.message != "Expected 'xxxxxxxxxx…' to contain 'nothing'" {
+		t.FailNow()
+	}
+	t.Setenv(maxLenEnvVar, "0")
+	if Contains(rt, expected, "nothing") || rt.Passed() {
+		t.FailNow()
+	}
+	if rt.message != "Expected '"+expected+"' to contain 'nothing'" {
+		t.FailNow()
+	}
+	if tt.Contains(expected, "nothing") || rt.Passed() {
+		t.FailNow()
+	}
+	if rt.message != "Expected 'xxxxxxxxxxxxxxxxxxxx…' to contain 'nothing'" {
+		t.FailNow()
+	}
+}
+
+func Test_MaxLenPairs(t *testing.T) {
+	rt := &RecordingTestingT{}
+
+	expected := strings.Repeat("x", 5000) + "expected"
+	actual := strings.Repeat("x", 5000) + "actual"
+
+	if EqualT(rt, expected, actual, MaxLen(20)) || rt.Passed() {
+		t.FailNow()
+	}
+	if rt.message != "Expected '…xxxxxxxxxxxxexpected', actual '…xxxxxxxxxxxxactual'\nFirst difference at offset 5000" {
+		t.FailNow()
+	}
+	if ContainsEntry(rt, map[string]string{"k": actual}, "k", expected, MaxLen(20)) || rt.Passed() {
+		t.FailNow()
+	}
+	if rt.message != "Expected '…xxxxxxxxxxxxexpected' at key 'k', actual '…xxxxxxxxxxxxactual'\nFirst difference at offset 5000" {
+		t.FailNow()
+	}
+	if MapSubset(rt, map[string]string{"k": actual}, map[string]string{"k": expected}, MaxLen(20)) || rt.Passed() {
+		t.FailNow()
+	}
+	if !strings.Contains(rt.message, "Key 'k': expected '…xxxxxxxxxxxxexpected', actual '…xxxxxxxxxxxxactual'\nFirst difference at offset 5000") {
+		t.FailNow()
+	}
+}
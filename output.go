@@ -0,0 +1,151 @@
+/*
+Copyright 2024-2025 Microbus LLC and various contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testarossa
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sync/atomic"
+)
+
+// Output is the output captured by CaptureOutput.
+type Output struct {
+	Stdout string
+	Stderr string
+}
+
+var (
+	// capturing indicates if output is being captured, which is global to the process.
+	capturing atomic.Bool
+	// stdout is where testarossa prints failures while output is captured.
+	stdout atomic.Pointer[os.File]
+)
+
+/*
+CaptureOutput runs the function and returns what it wrote to stdout and stderr.
+The file descriptors of stdout and stderr are redirected, so that output from other goroutines and from C code is captured too,
+except on operating systems other than Unix, where only os.Stdout and os.Stderr are redirected.
+Failures of assertions made by the function are printed to the original stdout, and are not captured.
+Output is captured by one call at a time, so output of parallel tests may be captured by another test.
+A call made while another call is capturing output, whether by its function or by a parallel test,
+fails the test and runs its function without capturing its output.
+
+	out := testarossa.CaptureOutput(t, func() {
+		cmd.Execute()
+	})
+	testarossa.Contains(t, out.Stderr, "Usage:")
+*/
+func CaptureOutput(t TestingT, fn func()) (out Output) {
+	if !capturing.CompareAndSwap(false, true) {
+		fail(t, true, "Output is already being captured")
+		fn()
+		return Output{}
+	}
+	defer capturing.Store(false)
+
+	stdoutCapture, err := startCapture(&os.Stdout)
+	if err != nil {
+		fail(t, true, "Failed to capture stdout: %s", err.Error())
+		fn()
+		return Output{}
+	}
+	stdout.Store(stdoutCapture.original)
+	stderrCapture, err := startCapture(&os.Stderr)
+	if err != nil {
+		stdout.Store(nil)
+		stdoutCapture.stop()
+		fail(t, true, "Failed to capture stderr: %s", err.Error())
+		fn()
+		return Output{}
+	}
+	defer func() {
+		// Print failures to stdout before the original file is invalidated by restoring it
+		stdout.Store(nil)
+		out.Stderr = stderrCapture.stop()
+		out.Stdout = stdoutCapture.stop()
+	}()
+	fn()
+	return out
+}
+
+// capture captures the output written to a file through a pipe.
+type capture struct {
+	original *os.File
+	restore  func()
+	r        *os.File
+	w        *os.File
+	buf      bytes.Buffer
+	done     chan struct{}
+}
+
+// startCapture redirects the output written to the file to a pipe, and starts collecting it.
+func startCapture(f **os.File) (*capture, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	original, restore, err := redirectOutput(f, w)
+	if err != nil {
+		r.Close()
+		w.Close()
+		return nil, err
+	}
+	c := &capture{
+		original: original,
+		restore:  restore,
+		r:        r,
+		w:        w,
+		done:     make(chan struct{}),
+	}
+	go func() {
+		io.Copy(&c.buf, r)
+		close(c.done)
+	}()
+	return c, nil
+}
+
+// stop restores the output of the file and returns the output that was captured.
+func (c *capture) stop() string {
+	c.restore()
+	// The pipe is closed only after the file no longer refers to it
+	c.w.Close()
+	<-c.done
+	c.r.Close()
+	return c.buf.String()
+}
+
+// printf prints output of testarossa, such as failures, to stdout.
+// While output is captured, it is printed to the original stdout.
+func printf(format string, args ...any) {
+	w := stdout.Load()
+	if w == nil {
+		w = os.Stdout
+	}
+	fmt.Fprintf(w, format, args...)
+}
+
+// OutputEqual fails the test if what the function writes to stdout is not equal to the expected string.
+func OutputEqual(t TestingT, expected string, fn func(), args ...any) bool {
+	return Equal(t, expected, CaptureOutput(t, fn).Stdout, args...)
+}
+
+// OutputContains fails the test if what the function writes to stdout does not contain the substring.
+func OutputContains(t TestingT, substr string, fn func(), args ...any) bool {
+	return Contains(t, CaptureOutput(t, fn).Stdout, substr, args...)
+}
@@ -0,0 +1,45 @@
+//go:build linux
+
+/*
+Copyright 2024-2025 Microbus LLC and various contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testarossa
+
+import (
+	"os"
+	"syscall"
+)
+
+// redirectOutput points the file descriptor of the file to the pipe.
+// It returns a file for the original file descriptor, and a function that restores it.
+func redirectOutput(f **os.File, w *os.File) (original *os.File, restore func(), err error) {
+	fd := int((*f).Fd())
+	saved, err := syscall.Dup(fd)
+	if err != nil {
+		return nil, nil, err
+	}
+	err = syscall.Dup3(int(w.Fd()), fd, 0)
+	if err != nil {
+		syscall.Close(saved)
+		return nil, nil, err
+	}
+	original = os.NewFile(uintptr(saved), (*f).Name())
+	restore = func() {
+		syscall.Dup3(saved, fd, 0)
+		original.Close()
+	}
+	return original, restore, nil
+}
@@ -0,0 +1,35 @@
+//go:build !unix
+
+/*
+Copyright 2024-2025 Microbus LLC and various contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testarossa
+
+import (
+	"os"
+)
+
+// redirectOutput points the file to the pipe.
+// Only the variable is replaced, so output written to the file descriptor directly is not redirected.
+// It returns the original file, and a function that restores it.
+func redirectOutput(f **os.File, w *os.File) (original *os.File, restore func(), err error) {
+	original = *f
+	*f = w
+	restore = func() {
+		*f = original
+	}
+	return original, restore, nil
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2024-2025 Microbus LLC and various contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testarossa
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+func TestOutput_Capture(t *testing.T) {
+	mt := &MockTestingT{}
+	out := CaptureOutput(mt, func() {
+		fmt.Print("out")
+		fmt.Fprint(os.Stderr, "err")
+		done := make(chan struct{})
+		go func() {
+			fmt.Print(" from goroutine")
+			close(done)
+		}()
+		<-done
+	})
+	if out.Stdout != "out from goroutine" || out.Stderr != "err" || mt.Failed() {
+		t.Fatal(out)
+	}
+
+	// Nothing written
+	out = For(mt).CaptureOutput(func() {})
+	if out.Stdout != "" || out.Stderr != "" {
+		t.FailNow()
+	}
+
+	// Restored
+	if stdout.Load() != nil {
+		t.FailNow()
+	}
+}
+
+func TestOutput_FileDescriptor(t *testing.T) {
+	if !GOOS("linux", "darwin", "freebsd") {
+		t.Skip("Requires Unix")
+	}
+	out := CaptureOutput(t, func() {
+		syscall.Write(1, []byte("direct"))
+	})
+	if out.Stdout != "direct" {
+		t.Fatal(out)
+	}
+}
+
+func TestOutput_FailuresNotCaptured(t *testing.T) {
+	mt := &MockTestingT{}
+	out := CaptureOutput(mt, func() {
+		fmt.Print("before")
+		Equal(mt, 1, 2)
+		fmt.Print("after")
+	})
+	if out.Stdout != "beforeafter" || strings.Contains(out.Stdout, "--- FAIL") || !mt.Failed() {
+		t.Fatal(out)
+	}
+}
+
+func TestOutput_Panic(t *testing.T) {
+	func() {
+		defer func() {
+			recover()
+		}()
+		CaptureOutput(t, func() {
+			panic("boom")
+		})
+	}()
+	if stdout.Load() != nil {
+		t.FailNow()
+	}
+	out := CaptureOutput(t, func() {
+		fmt.Print("x")
+	})
+	if out.Stdout != "x" {
+		t.FailNow()
+	}
+}
+
+func TestOutput_Assertions(t *testing.T) {
+	mt := &MockTestingT{}
+	hello := func() {
+		fmt.Println("Hello, World")
+	}
+	if !OutputEqual(mt, "Hello, World\n", hello) || mt.Failed() {
+		t.FailNow()
+	}
+	if OutputEqual(mt, "Hello", hello) || mt.Passed() {
+		t.FailNow()
+	}
+	if !For(mt).OutputContains("World", hello) || mt.Failed() {
+		t.FailNow()
+	}
+	if For(mt).OutputEqual("Hello", hello) || mt.Passed() {
+		t.FailNow()
+	}
+	if OutputContains(mt, "Moon", hello) || mt.Passed() {
+		t.FailNow()
+	}
+}
+
+func TestOutput_Nested(t *testing.T) {
+	mt := &MockTestingT{}
+	var inner Output
+	out := CaptureOutput(mt, func() {
+		inner = CaptureOutput(mt, func() {
+			fmt.Print("inner")
+		})
+		fmt.Print("outer")
+	})
+	if !mt.Failed() || inner.Stdout != "" || out.Stdout != "innerouter" {
+		t.Fatal(out, inner)
+	}
+
+	// Sequential calls are unaffected
+	mt = &MockTestingT{}
+	out = CaptureOutput(mt, func() {
+		fmt.Print("again")
+	})
+	if mt.Failed() || out.Stdout != "again" {
+		t.Fatal(out)
+	}
+}
+
+func TestOutput_NestedInGoroutine(t *testing.T) {
+	mt := &MockTestingT{}
+	var inner Output
+	out := CaptureOutput(mt, func() {
+		done := make(chan struct{})
+		go func() {
+			inner = CaptureOutput(mt, func() {
+				fmt.Print("inner")
+			})
+			close(done)
+		}()
+		<-done
+	})
+	if !mt.Failed() || inner.Stdout != "" || out.Stdout != "inner" {
+		t.Fatal(out, inner)
+	}
+}
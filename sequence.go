@@ -0,0 +1,235 @@
+/*
+Copyright 2024-2025 Microbus LLC and various contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testarossa
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+)
+
+// Number of elements or bytes shown on each side of the offending position in excerpts.
+const (
+	excerptRadiusElements = 3
+	excerptRadiusBytes    = 32
+)
+
+// Sorted fails the test if the slice is not sorted in ascending order.
+func Sorted[S ~[]E, E cmp.Ordered](t TestingT, slice S, args ...any) bool {
+	countAssertion(t)
+	for i := 1; i < len(slice); i++ {
+		if cmp.Less(slice[i], slice[i-1]) {
+			msgArgs := []any{"Expected sorted, element at index %d is out of order", i, "Excerpt %s", excerptElements(anySlice(slice), i)}
+			fail(
+				t,
+				true,
+				append(msgArgs, args...)...,
+			)
+			return false
+		}
+	}
+	return true
+}
+
+// SortedFunc fails the test if the slice is not sorted in ascending order, as determined by the cmp function.
+// The cmp function should return a negative number when a < b, a positive number when a > b and zero when a == b.
+func SortedFunc[S ~[]E, E any](t TestingT, slice S, cmp func(a E, b E) int, args ...any) bool {
+	countAssertion(t)
+	for i := 1; i < len(slice); i++ {
+		if cmp(slice[i], slice[i-1]) < 0 {
+			msgArgs := []any{"Expected sorted, element at index %d is out of order", i, "Excerpt %s", excerptElements(anySlice(slice), i)}
+			fail(
+				t,
+				true,
+				append(msgArgs, args...)...,
+			)
+			return false
+		}
+	}
+	return true
+}
+
+// Unique fails the test if the slice or array contains duplicate elements.
+// The indexes of each set of duplicates are reported.
+func Unique(t TestingT, slice any, args ...any) bool {
+	countAssertion(t)
+	elems, ok := elementsOf(t, slice, args)
+	if !ok {
+		return false
+	}
+	var msgArgs []any
+	reported := make([]bool, len(elems))
+	for i := range elems {
+		if reported[i] {
+			continue
+		}
+		dupIndexes := []int{i}
+		for j := i + 1; j < len(elems); j++ {
+			if !reported[j] && reflect.DeepEqual(elems[i], elems[j]) {
+				reported[j] = true
+				dupIndexes = append(dupIndexes, j)
+			}
+		}
+		if len(dupIndexes) > 1 {
+			msgArgs = append(msgArgs, "Duplicate '%v' at indexes %v", v(elems[i]), dupIndexes)
+		}
+	}
+	if len(msgArgs) == 0 {
+		return true
+	}
+	msgArgs = append([]any{"Expected unique elements"}, msgArgs...)
+	fail(
+		t,
+		true,
+		append(msgArgs, args...)...,
+	)
+	return false
+}
+
+/*
+ContainsInOrder fails the test if the subs do not appear in the whole in the given relative order.
+If the whole is a string, error or byte slice, the subs are substrings.
+If the whole is a slice or array, the subs are elements.
+
+	ContainsInOrder(t, logOutput, "Starting", "Listening on port", "Shutting down")
+*/
+func ContainsInOrder(t TestingT, whole any, subs ...any) bool {
+	countAssertion(t)
+	if isNil(whole) {
+		fail(
+			t,
+			true,
+			"Nil is not a container",
+		)
+		return false
+	}
+	if err, ok := whole.(error); ok {
+		whole = err.Error()
+	}
+	if b, ok := whole.([]byte); ok {
+		whole = string(b)
+	}
+	if w, ok := whole.(string); ok {
+		pos := 0
+		for i, sub := range subs {
+			if b, ok := sub.([]byte); ok {
+				sub = string(b)
+			}
+			s, ok := sub.(string)
+			if !ok {
+				fail(
+					t,
+					true,
+					"Type %v of sub %d is not a string", reflect.TypeOf(sub), i,
+				)
+				return false
+			}
+			p := strings.Index(w[pos:], s)
+			if p < 0 {
+				msgArgs := []any{"Expected '%v' at or after position %d", v(s), pos}
+				if strings.Contains(w, s) {
+					msgArgs = append(msgArgs, "Found out of order at position %d", strings.Index(w, s))
+				}
+				msgArgs = append(msgArgs, "Excerpt '%v'", excerptString(w, pos))
+				fail(
+					t,
+					true,
+					msgArgs...,
+				)
+				return false
+			}
+			pos += p + len(s)
+		}
+		return true
+	}
+	elems, ok := elementsOf(t, whole, nil)
+	if !ok {
+		return false
+	}
+	idx := 0
+	for _, sub := range subs {
+		p := indexOfElement(elems[idx:], sub)
+		if p < 0 {
+			msgArgs := []any{"Expected '%v' at or after index %d", v(sub), idx}
+			if p = indexOfElement(elems, sub); p >= 0 {
+				msgArgs = append(msgArgs, "Found out of order at index %d", p)
+			}
+			msgArgs = append(msgArgs, "Excerpt %s", excerptElements(elems, idx))
+			fail(
+				t,
+				true,
+				msgArgs...,
+			)
+			return false
+		}
+		idx += p + 1
+	}
+	return true
+}
+
+// anySlice converts a typed slice to a slice of any.
+func anySlice[S ~[]E, E any](slice S) []any {
+	elems := make([]any, len(slice))
+	for i := range slice {
+		elems[i] = slice[i]
+	}
+	return elems
+}
+
+// excerptElements renders the elements around the index, prefixed by their index.
+func excerptElements(elems []any, index int) string {
+	from := max(0, index-excerptRadiusElements)
+	to := min(len(elems), index+excerptRadiusElements+1)
+	var sb strings.Builder
+	sb.WriteString("[")
+	if from > 0 {
+		sb.WriteString("… ")
+	}
+	for i := from; i < to; i++ {
+		if i > from {
+			sb.WriteString(" ")
+		}
+		fmt.Fprintf(&sb, "%d:'%s'", i, v(elems[i]))
+	}
+	if to < len(elems) {
+		sb.WriteString(" …")
+	}
+	sb.WriteString("]")
+	return sb.String()
+}
+
+// excerptString renders the text around the byte position.
+func excerptString(s string, pos int) string {
+	from := max(0, pos-excerptRadiusBytes)
+	to := min(len(s), pos+excerptRadiusBytes)
+	for from > 0 && !utf8.RuneStart(s[from]) {
+		from--
+	}
+	for to < len(s) && !utf8.RuneStart(s[to]) {
+		to++
+	}
+	excerpt := s[from:to]
+	if from > 0 {
+		excerpt = "…" + excerpt
+	}
+	if to < len(s) {
+		excerpt += "…"
+	}
+	return excerpt
+}
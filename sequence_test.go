@@ -0,0 +1,131 @@
+/*
+Copyright 2024-2025 Microbus LLC and various contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testarossa
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func Test_Sorted(t *testing.T) {
+	mt := &MockTestingT{}
+
+	if !Sorted(mt, []int{1, 2, 2, 3}) || mt.Failed() {
+		t.FailNow()
+	}
+	if !Sorted(mt, []int{}) || mt.Failed() {
+		t.FailNow()
+	}
+	if !Sorted(mt, []string{"a", "b", "c"}) || mt.Failed() {
+		t.FailNow()
+	}
+	if Sorted(mt, []int{1, 2, 3, 4, 5, 0, 6, 7, 8, 9, 10}) || mt.Passed() {
+		t.FailNow()
+	}
+
+	type E struct {
+		X int
+	}
+	byX := func(a, b E) int {
+		return a.X - b.X
+	}
+	if !SortedFunc(mt, []E{{1}, {2}, {3}}, byX) || mt.Failed() {
+		t.FailNow()
+	}
+	if SortedFunc(mt, []E{{1}, {3}, {2}}, byX) || mt.Passed() {
+		t.FailNow()
+	}
+}
+
+func Test_Unique(t *testing.T) {
+	mt := &MockTestingT{}
+
+	if !Unique(mt, []int{1, 2, 3}) || mt.Failed() {
+		t.FailNow()
+	}
+	if !Unique(mt, nil) || mt.Failed() {
+		t.FailNow()
+	}
+	if Unique(mt, []int{1, 2, 1, 3, 2, 1}) || mt.Passed() {
+		t.FailNow()
+	}
+	if Unique(mt, [][]int{{1}, {1}}) || mt.Passed() {
+		t.FailNow()
+	}
+	if Unique(mt, "abc") || mt.Passed() {
+		t.FailNow()
+	}
+}
+
+func Test_ContainsInOrder(t *testing.T) {
+	mt := &MockTestingT{}
+
+	log := "Starting\nListening on port 8080\nShutting down"
+	if !ContainsInOrder(mt, log, "Starting", "Listening", "Shutting down") || mt.Failed() {
+		t.FailNow()
+	}
+	if !ContainsInOrder(mt, []byte(log), []byte("Starting"), "down") || mt.Failed() {
+		t.FailNow()
+	}
+	if !ContainsInOrder(mt, errors.New(log), "port", "down") || mt.Failed() {
+		t.FailNow()
+	}
+	if ContainsInOrder(mt, log, "Listening", "Starting") || mt.Passed() {
+		t.FailNow()
+	}
+	if ContainsInOrder(mt, log, "Starting", "Stopping") || mt.Passed() {
+		t.FailNow()
+	}
+	if ContainsInOrder(mt, "abab", "ab", "ab", "ab") || mt.Passed() {
+		t.FailNow()
+	}
+	if ContainsInOrder(mt, log, 1) || mt.Passed() {
+		t.FailNow()
+	}
+	if ContainsInOrder(mt, nil, "x") || mt.Passed() {
+		t.FailNow()
+	}
+
+	if !ContainsInOrder(mt, []int{1, 2, 3, 4, 5}, 2, 4, 5) || mt.Failed() {
+		t.FailNow()
+	}
+	if ContainsInOrder(mt, []int{1, 2, 3, 4, 5}, 4, 2) || mt.Passed() {
+		t.FailNow()
+	}
+	if ContainsInOrder(mt, []int{1, 2, 3}, 3, 3) || mt.Passed() {
+		t.FailNow()
+	}
+	if ContainsInOrder(mt, 123, 1) || mt.Passed() {
+		t.FailNow()
+	}
+}
+
+func Test_Excerpt(t *testing.T) {
+	tt := For(t)
+
+	tt.Equal("[0:'a' 1:'b' 2:'c']", excerptElements([]any{"a", "b", "c"}, 1))
+	tt.Equal("[… 2:'2' 3:'3' 4:'4' 5:'5' 6:'6' 7:'7' 8:'8' …]", excerptElements([]any{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, 5))
+
+	tt.Equal("hello", excerptString("hello", 2))
+	long := strings.Repeat("a", 50) + "X" + strings.Repeat("b", 50)
+	excerpt := excerptString(long, 50)
+	tt.True(strings.HasPrefix(excerpt, "…"))
+	tt.True(strings.HasSuffix(excerpt, "…"))
+	tt.Contains(excerpt, "X")
+	tt.Contains(excerptString(strings.Repeat("é", 100), 101), "é")
+}
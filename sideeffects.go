@@ -0,0 +1,149 @@
+/*
+Copyright 2024-2025 Microbus LLC and various contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testarossa
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+/*
+Watch takes a snapshot of a piece of global state and returns a function that lists
+the changes made to it since the snapshot.
+*/
+type Watch func() (changed func() []string)
+
+/*
+NoSideEffects takes a snapshot of the environment variables, the working directory, the umask and time.Local,
+as well as of the global state of the watches, and returns a function that fails the test if any of them changed.
+The failure lists each change along with the values before and after it.
+The function can be deferred or registered as a cleanup function.
+Changes made by t.Setenv and t.Chdir are reverted before cleanup functions registered earlier run,
+so NoSideEffects should be registered as a cleanup function rather than deferred in tests that use them.
+
+	t.Cleanup(testarossa.NoSideEffects(t, testarossa.Global("http.DefaultClient", &http.DefaultClient)))
+*/
+func NoSideEffects(t TestingT, watch ...Watch) func() {
+	watches := append([]Watch{watchEnv, watchWorkingDir, watchUmask, Global("time.Local", &time.Local)}, watch...)
+	return checkAtEnd(t, "Detected %d side effect(s)", false, watches)
+}
+
+/*
+Global watches the package-level variable that ptr points to.
+The variable is considered changed if the way its value is shown in failure messages changes,
+whether because it is assigned a different value or because the contents of the map, slice or struct it holds change.
+
+	testarossa.Global("config.Default", &config.Default)
+*/
+func Global(name string, ptr any) Watch {
+	return func() func() []string {
+		ptrValue := reflect.ValueOf(ptr)
+		if ptrValue.Kind() != reflect.Pointer || ptrValue.IsNil() {
+			return func() []string {
+				return []string{fmt.Sprintf("Global %s is not a pointer to a variable", name)}
+			}
+		}
+		beforeStr := render(ptrValue.Elem().Interface())
+		return func() []string {
+			afterStr := render(ptrValue.Elem().Interface())
+			if afterStr == beforeStr {
+				return nil
+			}
+			return []string{fmt.Sprintf("Global %s changed from %s to %s", name, quote(beforeStr), quote(afterStr))}
+		}
+	}
+}
+
+// watchEnv watches the environment variables.
+func watchEnv() func() []string {
+	before := environ()
+	return func() (changed []string) {
+		after := environ()
+		var names []string
+		for name := range before {
+			names = append(names, name)
+		}
+		for name := range after {
+			if _, ok := before[name]; !ok {
+				names = append(names, name)
+			}
+		}
+		slices.Sort(names)
+		for _, name := range names {
+			b, wasSet := before[name]
+			a, isSet := after[name]
+			if wasSet == isSet && a == b {
+				continue
+			}
+			beforeStr, afterStr := "unset", "unset"
+			if wasSet {
+				beforeStr = quote(b)
+			}
+			if isSet {
+				afterStr = quote(a)
+			}
+			changed = append(changed, fmt.Sprintf("Environment variable %s changed from %s to %s", name, beforeStr, afterStr))
+		}
+		return changed
+	}
+}
+
+// environ maps the names of the environment variables to their values.
+func environ() map[string]string {
+	env := map[string]string{}
+	for _, kv := range os.Environ() {
+		name, val, _ := strings.Cut(kv, "=")
+		env[name] = val
+	}
+	return env
+}
+
+// watchWorkingDir watches the working directory.
+func watchWorkingDir() func() []string {
+	before, _ := os.Getwd()
+	return func() []string {
+		after, _ := os.Getwd()
+		if after == before {
+			return nil
+		}
+		return []string{fmt.Sprintf("Working directory changed from %s to %s", quote(before), quote(after))}
+	}
+}
+
+// watchUmask watches the umask of the process.
+// It has no effect on operating systems that do not have one.
+// On operating systems other than Linux, the umask is read by momentarily setting it to 0,
+// which affects files created concurrently by other goroutines.
+func watchUmask() func() []string {
+	before, ok := umask()
+	return func() []string {
+		after, _ := umask()
+		if !ok || after == before {
+			return nil
+		}
+		return []string{fmt.Sprintf("Umask changed from %04o to %04o", before, after)}
+	}
+}
+
+// quote quotes a value for a list of changes, truncated to the default length.
+func quote(s string) string {
+	return "'" + truncate(s, defaultOptions().maxLen) + "'"
+}
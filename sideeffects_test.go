@@ -0,0 +1,192 @@
+/*
+Copyright 2024-2025 Microbus LLC and various contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testarossa
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	globalInt   = 1
+	globalMap   = map[string]int{"a": 1}
+	globalSlice = []int{1, 2}
+	globalAny   any
+	globalCfg   = sideEffectsConfig{Hosts: []string{"a"}}
+)
+
+type sideEffectsConfig struct {
+	Hosts []string
+}
+
+func TestSideEffects_Env(t *testing.T) {
+	t.Setenv("TESTAROSSA_SIDE_EFFECT", "before")
+	t.Setenv("TESTAROSSA_SIDE_EFFECT_NEW", "")
+	os.Unsetenv("TESTAROSSA_SIDE_EFFECT_NEW")
+
+	rt := &RecordingTestingT{}
+	check := NoSideEffects(rt)
+	os.Setenv("TESTAROSSA_SIDE_EFFECT", "after")
+	os.Setenv("TESTAROSSA_SIDE_EFFECT_NEW", "new")
+	check()
+	if rt.Passed() {
+		t.FailNow()
+	}
+	expected := "Detected 2 side effect(s)\n" +
+		"Environment variable TESTAROSSA_SIDE_EFFECT changed from 'before' to 'after'\n" +
+		"Environment variable TESTAROSSA_SIDE_EFFECT_NEW changed from unset to 'new'"
+	if rt.message != expected {
+		t.Fatal(rt.message)
+	}
+	if !strings.HasSuffix(rt.file, "sideeffects_test.go") {
+		t.FailNow()
+	}
+
+	check = NoSideEffects(rt)
+	os.Unsetenv("TESTAROSSA_SIDE_EFFECT_NEW")
+	check()
+	if rt.Passed() || !strings.HasSuffix(rt.message, "TESTAROSSA_SIDE_EFFECT_NEW changed from 'new' to unset") {
+		t.Fatal(rt.message)
+	}
+
+	check = For(rt).NoSideEffects()
+	check()
+	if rt.Failed() {
+		t.FailNow()
+	}
+}
+
+func TestSideEffects_WorkingDirAndTimeLocal(t *testing.T) {
+	wd, _ := os.Getwd()
+	local := time.Local
+	defer func() {
+		os.Chdir(wd)
+		time.Local = local
+	}()
+
+	rt := &RecordingTestingT{}
+	check := NoSideEffects(rt)
+	os.Chdir("testarossatest")
+	time.Local = time.UTC
+	check()
+	if rt.Passed() {
+		t.FailNow()
+	}
+	if !strings.Contains(rt.message, "Working directory changed from '"+wd+"' to '"+wd+"/testarossatest'") {
+		t.Fatal(rt.message)
+	}
+	if !strings.Contains(rt.message, "Global time.Local changed from 'Local' to 'UTC'") {
+		t.Fatal(rt.message)
+	}
+}
+
+func TestSideEffects_Umask(t *testing.T) {
+	before, ok := umask()
+	if !ok {
+		t.Skip("No umask")
+	}
+	defer setUmask(before)
+
+	rt := &RecordingTestingT{}
+	check := NoSideEffects(rt)
+	setUmask(0o077)
+	check()
+	if rt.Passed() || !strings.Contains(rt.message, "Umask changed from ") || !strings.HasSuffix(rt.message, " to 0077") {
+		t.Fatal(rt.message)
+	}
+}
+
+func TestSideEffects_Global(t *testing.T) {
+	savedInt, savedMap, savedSlice, savedAny := globalInt, globalMap, globalSlice, globalAny
+	globalMap, globalSlice = map[string]int{"a": 1}, []int{1, 2}
+	t.Cleanup(func() {
+		globalInt, globalMap, globalSlice, globalAny = savedInt, savedMap, savedSlice, savedAny
+	})
+
+	rt := &RecordingTestingT{}
+	watches := []Watch{
+		Global("globalInt", &globalInt),
+		Global("globalMap", &globalMap),
+		Global("globalSlice", &globalSlice),
+		Global("globalAny", &globalAny),
+	}
+
+	check := NoSideEffects(rt, watches...)
+	check()
+	if rt.Failed() {
+		t.FailNow()
+	}
+
+	check = NoSideEffects(rt, watches...)
+	globalInt = 2
+	globalMap["b"] = 2
+	globalSlice[0] = 3
+	globalAny = []int{1}
+	check()
+	expected := "Detected 4 side effect(s)\n" +
+		"Global globalInt changed from '1' to '2'\n" +
+		"Global globalMap changed from 'map[a:1]' to 'map[a:1 b:2]'\n" +
+		"Global globalSlice changed from '[1 2]' to '[3 2]'\n" +
+		"Global globalAny changed from '<nil>' to '[1]'"
+	if rt.Passed() || rt.message != expected {
+		t.Fatal(rt.message)
+	}
+
+	// Interface holding a value that is not comparable
+	check = NoSideEffects(rt, watches...)
+	check()
+	if rt.Failed() {
+		t.FailNow()
+	}
+
+	// Reassigned with equal contents
+	check = NoSideEffects(rt, watches...)
+	globalSlice = []int{3, 2}
+	check()
+	if rt.Failed() {
+		t.FailNow()
+	}
+
+	check = NoSideEffects(rt, Global("nil", nil))
+	check()
+	if rt.Passed() || !strings.HasSuffix(rt.message, "Global nil is not a pointer to a variable") {
+		t.FailNow()
+	}
+}
+
+func TestSideEffects_GlobalUncomparable(t *testing.T) {
+	saved := globalCfg
+	t.Cleanup(func() {
+		globalCfg = saved
+	})
+
+	rt := &RecordingTestingT{}
+	check := NoSideEffects(rt, Global("cfg", &globalCfg))
+	check()
+	if rt.Failed() {
+		t.Fatal(rt.message)
+	}
+
+	check = NoSideEffects(rt, Global("cfg", &globalCfg))
+	globalCfg = sideEffectsConfig{Hosts: []string{"b"}}
+	check()
+	if rt.Passed() || !strings.HasSuffix(rt.message, "Global cfg changed from '{[a]}' to '{[b]}'") {
+		t.Fatal(rt.message)
+	}
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2024-2025 Microbus LLC and various contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testarossa
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+)
+
+/*
+SkipIf skips the test if the condition is met.
+The location of the skip is printed in the same format as failures.
+Tests that support skipping, as *testing.T and *testing.B do, stop running.
+Other tests are told that they are to be skipped by the return value, which is true if the condition is met.
+
+	testarossa.SkipIf(t, testarossa.Short(), "Slow test")
+	testarossa.SkipUnless(t, testarossa.BinaryOnPath("psql"), "Requires psql")
+*/
+func SkipIf(t TestingT, condition bool, args ...any) bool {
+	if h, ok := underlyingT(t).(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	return skipIf(t, condition, args)
+}
+
+// SkipUnless skips the test unless the condition is met.
+// It returns true if the test is to be skipped.
+func SkipUnless(t TestingT, condition bool, args ...any) bool {
+	if h, ok := underlyingT(t).(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	return skipIf(t, !condition, args)
+}
+
+// skipIf implements SkipIf and SkipUnless.
+func skipIf(t TestingT, condition bool, args []any) bool {
+	if !condition {
+		return false
+	}
+	frames := append(casePositions(t), stackFrames(nil)...)
+	skipTest(t, frames, formatMessage(args, optionsOf(t, args)))
+	return true
+}
+
+/*
+skipTest prints the frames that lead to the skip and the message, in the same format as failures, and skips the test.
+Tests that support skipping stop running.
+*/
+func skipTest(t TestingT, frames []frame, message string) {
+	var sb strings.Builder
+	for _, f := range frames {
+		sb.WriteString(fmt.Sprintf("    %s:%d\n", f.file, f.line))
+	}
+	if message != "" {
+		sb.WriteString("    ")
+		sb.WriteString(strings.ReplaceAll(message, "\n", "\n    "))
+		sb.WriteString("\n")
+	}
+	printf("--- SKIP: %s\n%s", t.Name(), sb.String())
+	switch skipper := underlyingT(t).(type) {
+	case interface{ SkipNow() }:
+		skipper.SkipNow()
+	case interface{ Skip(args ...any) }:
+		if message == "" {
+			skipper.Skip()
+		} else {
+			skipper.Skip(message)
+		}
+	}
+}
+
+// Short indicates if the tests are running in short mode, as set by the -short flag.
+func Short() bool {
+	return testing.Short()
+}
+
+// EnvSet indicates if the environment variable is set to a non-empty value.
+func EnvSet(name string) bool {
+	return os.Getenv(name) != ""
+}
+
+// BinaryOnPath indicates if the executable is found in the directories named by the PATH environment variable.
+func BinaryOnPath(name string) bool {
+	_, err := exec.LookPath(name)
+	return err == nil
+}
+
+// GOOS indicates if the tests are running on any of the operating systems, such as "linux" or "darwin".
+func GOOS(names ...string) bool {
+	return slices.Contains(names, runtime.GOOS)
+}
+
+// GOARCH indicates if the tests are running on any of the architectures, such as "amd64" or "arm64".
+func GOARCH(names ...string) bool {
+	return slices.Contains(names, runtime.GOARCH)
+}
+
+// RaceEnabled indicates if the tests are built with the race detector, as set by the -race flag.
+func RaceEnabled() bool {
+	return raceEnabled
+}
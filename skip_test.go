@@ -0,0 +1,100 @@
+/*
+Copyright 2024-2025 Microbus LLC and various contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testarossa
+
+import (
+	"runtime"
+	"testing"
+)
+
+// SkippingTestingT is a TestingT that supports skipping.
+type SkippingTestingT struct {
+	MockTestingT
+	skips   int
+	skipped []any
+}
+
+func (st *SkippingTestingT) Skip(args ...any) {
+	st.skips++
+	st.skipped = args
+}
+
+func TestSkip_SkipIf(t *testing.T) {
+	st := &SkippingTestingT{}
+	if SkipIf(st, false, "Never") || st.skipped != nil {
+		t.FailNow()
+	}
+	if !SkipIf(st, true, "Because of %s", "reasons") || len(st.skipped) != 1 || st.skipped[0] != "Because of reasons" {
+		t.FailNow()
+	}
+	if SkipUnless(st, true) || st.skips != 1 {
+		t.FailNow()
+	}
+	if !For(st).SkipUnless(false) || st.skips != 2 || len(st.skipped) != 0 || st.Failed() {
+		t.FailNow()
+	}
+}
+
+func TestSkip_Minimal(t *testing.T) {
+	mt := &MockTestingT{}
+	if SkipIf(mt, false) || !SkipIf(mt, true, "Skipped") || !For(mt).SkipUnless(false) {
+		t.FailNow()
+	}
+	if mt.Failed() {
+		t.FailNow()
+	}
+}
+
+func TestSkip_Testing(t *testing.T) {
+	skipped := false
+	t.Run("skip", func(t *testing.T) {
+		defer func() {
+			skipped = t.Skipped()
+		}()
+		SkipIf(t, true, "Skipped")
+		t.Fail()
+	})
+	if !skipped {
+		t.FailNow()
+	}
+}
+
+func TestSkip_Conditions(t *testing.T) {
+	if Short() != testing.Short() {
+		t.FailNow()
+	}
+	t.Setenv("TESTAROSSA_SKIP_TEST", "")
+	if EnvSet("TESTAROSSA_SKIP_TEST") {
+		t.FailNow()
+	}
+	t.Setenv("TESTAROSSA_SKIP_TEST", "1")
+	if !EnvSet("TESTAROSSA_SKIP_TEST") {
+		t.FailNow()
+	}
+	if !BinaryOnPath("go") || BinaryOnPath("testarossa-no-such-binary") {
+		t.FailNow()
+	}
+	if !GOOS("plan9", runtime.GOOS) || GOOS() || GOOS("no-such-os") {
+		t.FailNow()
+	}
+	if !GOARCH(runtime.GOARCH) || GOARCH("no-such-arch") {
+		t.FailNow()
+	}
+	if RaceEnabled() != raceEnabled {
+		t.FailNow()
+	}
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2024-2025 Microbus LLC and various contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testarossa
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// testState is the state that testarossa keeps for the duration of a test.
+type testState struct {
+	mux        sync.Mutex
+	assertions int
+	failures   int
+	// subtestAssertions are the assertions evaluated by the subtests of the test
+	subtestAssertions int
+	parent            *testState
+	// required are the frames that lead to RequireAssertions, or nil if it was not called
+	required []frame
+	hooks    []func(f Failure)
+}
+
+/*
+Stats are statistics of the assertions evaluated by tests.
+Only tests that support cleanup functions, as *testing.T and *testing.B do, are counted,
+and only once they completed.
+*/
+type Stats struct {
+	Tests      int
+	Assertions int
+	Failures   int
+}
+
+var (
+	// testStates are the states of the tests that are running, keyed by their TestingT.
+	testStates sync.Map
+	// stats are the statistics of the tests that completed.
+	stats    Stats
+	statsMux sync.Mutex
+)
+
+/*
+stateOf returns the state of the test, or nil if the test does not support cleanup functions.
+The state is created the first time testarossa sees the test, and a cleanup function is registered with the test
+to print a summary of its failures, count it towards the stats, and discard the state at the end of the test.
+The state is not created again once the test is finishing, so assertions evaluated by cleanup functions
+that run after the state is discarded count towards the stats directly.
+*/
+func stateOf(t TestingT) *testState {
+	t = underlyingT(t)
+	if state, ok := testStates.Load(t); ok {
+		return state.(*testState)
+	}
+	cleaner, ok := t.(interface{ Cleanup(func()) })
+	if !ok || finishing(t) {
+		return nil
+	}
+	state, loaded := testStates.LoadOrStore(t, &testState{})
+	if !loaded {
+		cleaner.Cleanup(func() {
+			state.(*testState).requireAssertions(t)
+			testStates.Delete(t)
+			state.(*testState).complete(t)
+		})
+	}
+	return state.(*testState)
+}
+
+// finishing indicates if the test is running its cleanup functions, as signaled by the cancellation of its context.
+func finishing(t TestingT) bool {
+	ctxer, ok := t.(interface{ Context() context.Context })
+	return ok && ctxer.Context().Err() != nil
+}
+
+// countAssertion counts an assertion evaluated by the test.
+func countAssertion(t TestingT) {
+	state := stateOf(t)
+	if state == nil {
+		if finishing(underlyingT(t)) {
+			statsMux.Lock()
+			stats.Assertions++
+			statsMux.Unlock()
+		}
+		return
+	}
+	state.mux.Lock()
+	state.assertions++
+	state.mux.Unlock()
+}
+
+// countFailure counts a failed assertion of the test.
+func countFailure(t TestingT) {
+	state := stateOf(t)
+	if state == nil {
+		if finishing(underlyingT(t)) {
+			statsMux.Lock()
+			stats.Failures++
+			statsMux.Unlock()
+		}
+		return
+	}
+	state.mux.Lock()
+	state.failures++
+	state.mux.Unlock()
+}
+
+// linkSubtest counts the assertions of the subtest towards its parent test.
+func linkSubtest(subtest TestingT, parent TestingT) {
+	subtestState := stateOf(subtest)
+	if subtestState == nil {
+		return
+	}
+	subtestState.mux.Lock()
+	subtestState.parent = stateOf(parent)
+	subtestState.mux.Unlock()
+}
+
+/*
+RequireAssertions fails the test if it completes without evaluating any assertions,
+which is often a sign of a bug in the test itself, such as an empty table or a loop that never iterates.
+Assertions of subtests run by Table or Run count towards the test.
+Assertions evaluated by cleanup functions count too, provided they were registered after testarossa first saw the test,
+for example after the call to For or RequireAssertions.
+It has no effect if the test does not support cleanup functions.
+
+	func TestAll(t *testing.T) {
+		testarossa.RequireAssertions(t)
+		for _, tc := range loadCases() {
+			testarossa.Equal(t, tc.expected, run(tc.input))
+		}
+	}
+*/
+func RequireAssertions(t TestingT) {
+	state := stateOf(t)
+	if state == nil {
+		return
+	}
+	frames := append(casePositions(t), stackFrames(nil)...)
+	state.mux.Lock()
+	state.required = frames
+	state.mux.Unlock()
+}
+
+// requireAssertions fails the test if RequireAssertions was called and no assertions were evaluated.
+func (state *testState) requireAssertions(t TestingT) {
+	state.mux.Lock()
+	required := state.required
+	assertions := state.assertions + state.subtestAssertions
+	state.mux.Unlock()
+	if required != nil && assertions == 0 {
+		failAt(t, required, []any{"Expected at least one assertion"})
+	}
+}
+
+// complete counts the test towards the stats and its parent test, and prints a summary of its failures.
+func (state *testState) complete(t TestingT) {
+	state.mux.Lock()
+	assertions := state.assertions
+	failures := state.failures
+	parent := state.parent
+	subtestAssertions := state.subtestAssertions
+	state.mux.Unlock()
+
+	if parent != nil {
+		parent.mux.Lock()
+		parent.subtestAssertions += assertions + subtestAssertions
+		parent.mux.Unlock()
+	}
+	statsMux.Lock()
+	stats.Tests++
+	stats.Assertions += assertions
+	stats.Failures += failures
+	statsMux.Unlock()
+
+	if failures < 2 {
+		return
+	}
+	summary := fmt.Sprintf("%d of %d assertions failed", failures, assertions)
+	if logger, ok := t.(interface {
+		Logf(format string, args ...any)
+	}); ok {
+		if h, ok := t.(interface{ Helper() }); ok {
+			h.Helper()
+		}
+		logger.Logf("%s", summary)
+		return
+	}
+	printf("--- FAIL: %s\n    %s\n", t.Name(), summary)
+}
+
+// Summary returns the statistics of the assertions evaluated by the tests that completed.
+func Summary() Stats {
+	statsMux.Lock()
+	defer statsMux.Unlock()
+	return stats
+}
+
+/*
+PrintSummary prints the statistics of the assertions evaluated by the tests that completed.
+It is meant to be called from TestMain after the tests ran.
+
+	func TestMain(m *testing.M) {
+		code := m.Run()
+		testarossa.PrintSummary()
+		os.Exit(code)
+	}
+*/
+func PrintSummary() {
+	s := Summary()
+	printf("testarossa: %d tests, %d assertions, %d failed\n", s.Tests, s.Assertions, s.Failures)
+}
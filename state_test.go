@@ -0,0 +1,174 @@
+/*
+Copyright 2024-2025 Microbus LLC and various contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testarossa
+
+import (
+	"fmt"
+	"testing"
+)
+
+// CleaningTestingT is a TestingT that supports cleanup functions and logging.
+type CleaningTestingT struct {
+	MockTestingT
+	cleanups []func()
+	logs     []string
+	helpers  int
+}
+
+func (ct *CleaningTestingT) Cleanup(fn func()) {
+	ct.cleanups = append(ct.cleanups, fn)
+}
+
+func (ct *CleaningTestingT) Logf(format string, args ...any) {
+	ct.logs = append(ct.logs, fmt.Sprintf(format, args...))
+}
+
+func (ct *CleaningTestingT) Helper() {
+	ct.helpers++
+}
+
+func (ct *CleaningTestingT) cleanup() {
+	for i := len(ct.cleanups) - 1; i >= 0; i-- {
+		ct.cleanups[i]()
+	}
+	ct.cleanups = nil
+}
+
+func TestState_Summary(t *testing.T) {
+	ct := &CleaningTestingT{}
+	tt := For(ct)
+	tt.Equal(1, 2)
+	tt.With("a", 1).True(false)
+	tt.True(true)
+	if len(ct.cleanups) != 1 || ct.helpers == 0 {
+		t.FailNow()
+	}
+	if stateOf(ct) == nil || stateOf(ct).failures != 2 || stateOf(ct).assertions != 3 {
+		t.FailNow()
+	}
+	ct.cleanup()
+	if len(ct.logs) != 1 || ct.logs[0] != "2 of 3 assertions failed" {
+		t.FailNow()
+	}
+	if _, ok := testStates.Load(ct); ok {
+		t.FailNow()
+	}
+
+	// No summary for a single failure
+	ct.logs = nil
+	tt.Equal(1, 2)
+	ct.cleanup()
+	if len(ct.logs) != 0 {
+		t.FailNow()
+	}
+}
+
+func TestState_Minimal(t *testing.T) {
+	mt := &MockTestingT{}
+	Equal(mt, 1, 2)
+	if stateOf(mt) != nil {
+		t.FailNow()
+	}
+	if _, ok := testStates.Load(mt); ok {
+		t.FailNow()
+	}
+}
+
+func TestState_CountAssertions(t *testing.T) {
+	ct := &CleaningTestingT{}
+	tt := For(ct)
+	True(ct, true)
+	tt.Equal(1, 1)
+	tt.That(1).IsGreaterThan(0)
+	FailIf(ct, false)
+	FailIfError(ct, nil)
+	Expect(ct, 1, 1, 2, 2)
+	SliceLen(ct, []int{1}, 1)
+	Assertion(ct).FailIf(false)
+	ReceivesEqual(ct, make(chan int, 1), 0, 0)
+	if stateOf(ct).assertions != 9 || stateOf(ct).failures != 1 {
+		t.Fatal(stateOf(ct).assertions)
+	}
+
+	before := Summary()
+	ct.cleanup()
+	after := Summary()
+	if after.Tests != before.Tests+1 || after.Assertions != before.Assertions+9 || after.Failures != before.Failures+1 {
+		t.FailNow()
+	}
+}
+
+func TestState_RequireAssertions(t *testing.T) {
+	ct := &CleaningTestingT{}
+	RequireAssertions(ct)
+	ct.cleanup()
+	if !ct.Failed() {
+		t.FailNow()
+	}
+
+	ct = &CleaningTestingT{}
+	For(ct).RequireAssertions()
+	True(ct, true)
+	ct.cleanup()
+	if ct.Failed() {
+		t.FailNow()
+	}
+
+	// No effect without cleanup functions
+	mt := &MockTestingT{}
+	RequireAssertions(mt)
+	if mt.Failed() {
+		t.FailNow()
+	}
+}
+
+func TestState_RequireAssertionsSubtests(t *testing.T) {
+	RequireAssertions(t)
+	Table(t, []tableCase{{name: "a"}}, func(tt *Asserter, tc tableCase) {
+		tt.Equal("a", tc.name)
+	})
+	if stateOf(t).assertions != 0 || stateOf(t).subtestAssertions != 1 {
+		t.FailNow()
+	}
+}
+
+func TestState_AssertionsInCleanup(t *testing.T) {
+	before := Summary()
+	t.Run("cleanup", func(t *testing.T) {
+		RequireAssertions(t)
+		t.Cleanup(func() {
+			Equal(t, 1, 1)
+		})
+	})
+	after := Summary()
+	if after.Tests != before.Tests+1 || after.Assertions != before.Assertions+1 {
+		t.FailNow()
+	}
+
+	// Cleanup function registered before the state is created
+	before = Summary()
+	t.Run("earlier", func(t *testing.T) {
+		t.Cleanup(func() {
+			Equal(t, 1, 1)
+		})
+		For(t).True(true)
+	})
+	after = Summary()
+	if after.Tests != before.Tests+1 || after.Assertions != before.Assertions+2 {
+		t.FailNow()
+	}
+}
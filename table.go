@@ -0,0 +1,318 @@
+/*
+Copyright 2024-2025 Microbus LLC and various contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testarossa
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"runtime"
+	"sync"
+	"testing"
+)
+
+/*
+Table runs each of the test cases as a subtest.
+The subtest is named after the name or Name string field of the case, if present.
+A case with a true focus or Focus bool field causes all cases that are not focused to be skipped,
+and a case with a true skip or Skip bool field is skipped.
+
+Failures of assertions made with the Asserter given to the function also point at the line of the case in the table,
+provided that the cases are a composite literal in the source code of the test.
+If t is an Asserter, its options carry over to the Asserter given to the function.
+
+	testarossa.Table(t, []struct {
+		name string
+		in   string
+		out  int
+	}{
+		{name: "empty", in: "", out: 0},
+		{name: "one", in: "x", out: 1},
+	}, func(tt *testarossa.Asserter, tc struct {
+		name string
+		in   string
+		out  int
+	}) {
+		tt.Equal(tc.out, len(tc.in))
+	})
+
+Table returns true if all cases passed.
+*/
+func Table[T any](t TestingT, cases []T, fn func(tt *Asserter, tc T)) bool {
+	var lines []int
+	_, file, line, ok := runtime.Caller(1)
+	if ok {
+		lines = caseLines(file, line, len(cases))
+	}
+	focused := false
+	for _, tc := range cases {
+		if caseFlag(tc, "focus", "Focus") {
+			focused = true
+			break
+		}
+	}
+	parent, ok := t.(*Asserter)
+	if !ok {
+		parent = For(t)
+	}
+	passed := true
+	for i, tc := range cases {
+		skip := caseFlag(tc, "skip", "Skip") || focused && !caseFlag(tc, "focus", "Focus")
+		var pos *frame
+		if lines != nil {
+			pos = &frame{file: file, line: lines[i]}
+		}
+		passed = runSubtest(t, caseName(tc), func(t TestingT) {
+			if skip {
+				var frames []frame
+				if pos != nil {
+					frames = []frame{*pos}
+				}
+				skipTest(t, frames, "")
+				return
+			}
+			tt := parent.derive(t)
+			if pos != nil {
+				tt.casePos = pos
+			}
+			fn(tt, tc)
+		}) && passed
+	}
+	return passed
+}
+
+// runSubtest runs the function as a subtest of the test, if the test supports subtests.
+// Otherwise, the function is run in the context of the test itself.
+// It returns true if the subtest passed.
+// The assertions of the subtest count towards the test.
+func runSubtest(t TestingT, name string, fn func(t TestingT)) bool {
+	switch parent := underlyingT(t).(type) {
+	case *testing.T:
+		stateOf(parent)
+		return parent.Run(name, func(t *testing.T) {
+			linkSubtest(t, parent)
+			fn(t)
+		})
+	case *testing.B:
+		stateOf(parent)
+		return parent.Run(name, func(b *testing.B) {
+			linkSubtest(b, parent)
+			fn(b)
+		})
+	}
+	// The tracker wraps the underlying test rather than t, so that the Asserter derived from it
+	// does not carry over the options and labels of t a second time
+	tracker := &Asserter{t: underlyingT(t)}
+	fn(tracker)
+	return !tracker.failed.Load()
+}
+
+// caseName returns the value of the name or Name string field of a test case,
+// or an empty string if there is no such field.
+func caseName(tc any) string {
+	if f, ok := caseField(tc, "name", "Name"); ok && f.Kind() == reflect.String {
+		return f.String()
+	}
+	return ""
+}
+
+// caseFlag returns the value of the first of the bool fields of a test case that is present.
+func caseFlag(tc any, fieldNames ...string) bool {
+	f, ok := caseField(tc, fieldNames...)
+	return ok && f.Kind() == reflect.Bool && f.Bool()
+}
+
+// caseField returns the first of the fields of a test case that is present.
+// The test case must be a struct or a pointer to a struct.
+func caseField(tc any, fieldNames ...string) (reflect.Value, bool) {
+	tcValue := reflect.ValueOf(tc)
+	if tcValue.Kind() == reflect.Pointer {
+		if tcValue.IsNil() {
+			return reflect.Value{}, false
+		}
+		tcValue = tcValue.Elem()
+	}
+	if tcValue.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+	for _, fieldName := range fieldNames {
+		if f := tcValue.FieldByName(fieldName); f.IsValid() {
+			return f, true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// caseLinesCache caches the lines of the cases of each call to Table.
+var caseLinesCache sync.Map
+
+/*
+caseLines returns the source line of each of the elements of the composite literal
+that is passed as the cases of the call to Table at the given file and line.
+The composite literal is either passed to Table directly, or assigned to the variable that is passed to it.
+It returns nil if the lines cannot be determined, or if the number of elements does not match the number of cases.
+*/
+func caseLines(file string, line int, count int) []int {
+	key := fmt.Sprintf("%s:%d", file, line)
+	if cached, ok := caseLinesCache.Load(key); ok {
+		lines, _ := cached.([]int)
+		if len(lines) != count {
+			return nil
+		}
+		return lines
+	}
+	lines := parseCaseLines(file, line)
+	caseLinesCache.Store(key, lines)
+	if len(lines) != count {
+		return nil
+	}
+	return lines
+}
+
+// parseCaseLines parses the source file to find the lines of the cases of the call to Table at the given line.
+func parseCaseLines(file string, line int) []int {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, file, nil, parser.SkipObjectResolution)
+	if err != nil {
+		return nil
+	}
+	// Find the innermost call to Table that spans the line
+	var call *ast.CallExpr
+	ast.Inspect(f, func(n ast.Node) bool {
+		c, ok := n.(*ast.CallExpr)
+		if !ok || len(c.Args) != 3 {
+			return true
+		}
+		if fset.Position(c.Pos()).Line > line || fset.Position(c.End()).Line < line {
+			return true
+		}
+		fun := c.Fun
+		switch x := fun.(type) {
+		case *ast.IndexExpr:
+			fun = x.X
+		case *ast.IndexListExpr:
+			fun = x.X
+		}
+		switch x := fun.(type) {
+		case *ast.Ident:
+			if x.Name == "Table" {
+				call = c
+			}
+		case *ast.SelectorExpr:
+			if x.Sel.Name == "Table" {
+				call = c
+			}
+		}
+		return true
+	})
+	if call == nil {
+		return nil
+	}
+	// Find the composite literal of the cases
+	var lit *ast.CompositeLit
+	switch arg := call.Args[1].(type) {
+	case *ast.CompositeLit:
+		lit = arg
+	case *ast.Ident:
+		// The last assignment to the variable before the call, in the innermost function that assigns to it,
+		// or else at the package level
+		for _, scope := range enclosingScopes(f, call) {
+			var assigned bool
+			lit, assigned = lastAssignedLit(scope, arg.Name, call.Pos())
+			if assigned {
+				break
+			}
+		}
+	}
+	if lit == nil {
+		return nil
+	}
+	lines := make([]int, 0, len(lit.Elts))
+	for _, elt := range lit.Elts {
+		if _, ok := elt.(*ast.KeyValueExpr); ok {
+			return nil
+		}
+		lines = append(lines, fset.Position(elt.Pos()).Line)
+	}
+	return lines
+}
+
+// enclosingScopes returns the functions that contain the call, from the innermost to the outermost,
+// followed by the declarations at the package level.
+func enclosingScopes(f *ast.File, call *ast.CallExpr) (scopes []ast.Node) {
+	ast.Inspect(f, func(n ast.Node) bool {
+		if n == nil || n.Pos() > call.Pos() || n.End() < call.End() {
+			return false
+		}
+		switch n.(type) {
+		case *ast.FuncDecl, *ast.FuncLit:
+			scopes = append([]ast.Node{n}, scopes...)
+		}
+		return true
+	})
+	for _, decl := range f.Decls {
+		if genDecl, ok := decl.(*ast.GenDecl); ok {
+			scopes = append(scopes, genDecl)
+		}
+	}
+	return scopes
+}
+
+/*
+lastAssignedLit returns the composite literal last assigned to the variable before the position, within the scope.
+Functions nested in the scope are not searched.
+It returns false if the variable is not assigned within the scope,
+and nil if the last assignment is not of a composite literal.
+*/
+func lastAssignedLit(scope ast.Node, name string, before token.Pos) (lit *ast.CompositeLit, assigned bool) {
+	ast.Inspect(scope, func(n ast.Node) bool {
+		if n == nil || n.Pos() >= before {
+			return false
+		}
+		var names []*ast.Ident
+		var values []ast.Expr
+		switch x := n.(type) {
+		case *ast.FuncLit:
+			return n == scope
+		case *ast.AssignStmt:
+			for _, lhs := range x.Lhs {
+				ident, _ := lhs.(*ast.Ident)
+				names = append(names, ident)
+			}
+			values = x.Rhs
+		case *ast.ValueSpec:
+			names = x.Names
+			values = x.Values
+		default:
+			return true
+		}
+		for i := range names {
+			if names[i] == nil || names[i].Name != name {
+				continue
+			}
+			assigned = true
+			lit = nil
+			if len(names) == len(values) {
+				lit, _ = values[i].(*ast.CompositeLit)
+			}
+		}
+		return true
+	})
+	return lit, assigned
+}
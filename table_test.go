@@ -0,0 +1,194 @@
+/*
+Copyright 2024-2025 Microbus LLC and various contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testarossa
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+type tableCase struct {
+	name  string
+	in    string
+	out   int
+	skip  bool
+	focus bool
+}
+
+func TestTable_Subtests(t *testing.T) {
+	var names []string
+	passed := Table(t, []tableCase{
+		{name: "empty", in: "", out: 0},
+		{name: "one", in: "x", out: 1},
+		{name: "skipped", in: "x", out: 2, skip: true},
+	}, func(tt *Asserter, tc tableCase) {
+		names = append(names, tt.Name())
+		tt.Equal(tc.out, len(tc.in))
+	})
+	if !passed {
+		t.FailNow()
+	}
+	if len(names) != 2 || names[0] != "TestTable_Subtests/empty" || names[1] != "TestTable_Subtests/one" {
+		t.FailNow()
+	}
+}
+
+func TestTable_Focus(t *testing.T) {
+	var ran []string
+	Table(t, []tableCase{
+		{name: "a"},
+		{name: "b", focus: true},
+		{name: "c"},
+		{name: "d", focus: true, skip: true},
+	}, func(tt *Asserter, tc tableCase) {
+		ran = append(ran, tc.name)
+	})
+	if len(ran) != 1 || ran[0] != "b" {
+		t.FailNow()
+	}
+}
+
+func TestTable_Failure(t *testing.T) {
+	mt := &MockTestingT{}
+	passed := Table(mt, []tableCase{
+		{name: "pass", in: "x", out: 1},
+		{name: "fail", in: "x", out: 2},
+	}, func(tt *Asserter, tc tableCase) {
+		tt.Equal(tc.out, len(tc.in))
+	})
+	if passed || mt.Passed() {
+		t.FailNow()
+	}
+	passed = Table(mt, []tableCase{
+		{name: "pass", in: "x", out: 1},
+	}, func(tt *Asserter, tc tableCase) {
+		tt.Equal(tc.out, len(tc.in))
+	})
+	if !passed || mt.Failed() {
+		t.FailNow()
+	}
+}
+
+func TestTable_CasePositions(t *testing.T) {
+	_, file, line, _ := runtime.Caller(0)
+	var positions []frame
+	Table(t, []tableCase{
+		{name: "a"},
+		{
+			name: "b",
+		},
+	}, func(tt *Asserter, tc tableCase) {
+		positions = append(positions, casePositions(tt)...)
+	})
+	if len(positions) != 2 {
+		t.FailNow()
+	}
+	if positions[0].file != file || positions[0].line != line+3 || positions[1].line != line+4 {
+		t.FailNow()
+	}
+
+	// Cases assigned to a variable
+	_, _, line, _ = runtime.Caller(0)
+	cases := []*tableCase{
+		{name: "a"}, {name: "b"},
+		{name: "c"},
+	}
+	positions = nil
+	Table(t, cases, func(tt *Asserter, tc *tableCase) {
+		positions = append(positions, casePositions(tt)...)
+	})
+	if len(positions) != 3 {
+		t.FailNow()
+	}
+	if positions[0].line != line+2 || positions[1].line != line+2 || positions[2].line != line+3 {
+		t.FailNow()
+	}
+
+	// Cases that are not a composite literal
+	cases = append(cases, &tableCase{name: "d"})
+	positions = nil
+	Table(t, cases, func(tt *Asserter, tc *tableCase) {
+		positions = append(positions, casePositions(tt)...)
+	})
+	if len(positions) != 0 {
+		t.FailNow()
+	}
+}
+
+func TestTable_NoNames(t *testing.T) {
+	var names []string
+	Table(t, []int{1, 2}, func(tt *Asserter, tc int) {
+		names = append(names, tt.Name())
+	})
+	if len(names) != 2 || names[0] != "TestTable_NoNames/#00" || names[1] != "TestTable_NoNames/#01" {
+		t.FailNow()
+	}
+}
+
+func TestTable_CaseLinesScope(t *testing.T) {
+	src := `package x
+
+func TestA(t *testing.T) {
+	cases := []tc{
+		{name: "a"},
+		{name: "b"},
+	}
+	Table(t, cases, nil)
+}
+
+func TestB(t *testing.T) {
+	cases := makeCases()
+	Table(t, cases, nil)
+}
+
+func TestC(t *testing.T) {
+	cases := []tc{
+		{name: "a"},
+	}
+	cases = makeCases()
+	Table(t, cases, nil)
+}
+
+var pkgCases = []tc{
+	{name: "a"},
+}
+
+func TestD(t *testing.T) {
+	t.Run("sub", func(t *testing.T) {
+		Table(t, pkgCases, nil)
+	})
+}
+`
+	file := filepath.Join(t.TempDir(), "x_test.go")
+	if err := os.WriteFile(file, []byte(src), 0o644); err != nil {
+		t.FailNow()
+	}
+	if lines := parseCaseLines(file, 8); len(lines) != 2 || lines[0] != 5 || lines[1] != 6 {
+		t.Fatal(lines)
+	}
+	if lines := parseCaseLines(file, 13); lines != nil {
+		t.Fatal(lines)
+	}
+	if lines := parseCaseLines(file, 21); lines != nil {
+		t.Fatal(lines)
+	}
+	if lines := parseCaseLines(file, 30); len(lines) != 1 || lines[0] != 25 {
+		t.Fatal(lines)
+	}
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2024-2025 Microbus LLC and various contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package testarossatest provides a recording test double of testarossa.TestingT
+for unit testing custom assertions the same way testarossa tests itself.
+
+	func TestPositiveTotal(t *testing.T) {
+		testarossatest.ExpectFailure(t, func(t testarossa.TestingT) {
+			PositiveTotal(t, Order{Total: -1})
+		}, "positive")
+		testarossatest.ExpectPass(t, func(t testarossa.TestingT) {
+			PositiveTotal(t, Order{Total: 1})
+		})
+	}
+*/
+package testarossatest
+
+import (
+	"fmt"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/microbus-io/testarossa"
+)
+
+// Failure is a failed assertion captured by the TestingT.
+type Failure struct {
+	Message string
+	File    string
+	Line    int
+	Fatal   bool
+	Labels  []testarossa.Label
+}
+
+// String returns the file:line of the failed assertion, followed by its labels, if any, and its message.
+func (f Failure) String() string {
+	if len(f.Labels) == 0 {
+		return fmt.Sprintf("%s:%d\n%s", f.File, f.Line, f.Message)
+	}
+	labels := make([]string, len(f.Labels))
+	for i, l := range f.Labels {
+		labels[i] = l.String()
+	}
+	return fmt.Sprintf("%s:%d\n%s\n%s", f.File, f.Line, strings.Join(labels, " "), f.Message)
+}
+
+// TestingT is a recorder that implements testarossa.TestingT.
+// Failures reported by testarossa are captured rather than printed.
+type TestingT struct {
+	name         string
+	failures     []Failure
+	failCalls    int
+	failNowCalls int
+	goexit       bool
+	mux          sync.Mutex
+}
+
+// New creates a new recorder with the given name.
+func New(name string) *TestingT {
+	return &TestingT{name: name}
+}
+
+// Fail marks the test as failed.
+func (t *TestingT) Fail() {
+	t.mux.Lock()
+	t.failCalls++
+	t.mux.Unlock()
+}
+
+// FailNow marks the test as failed.
+// When called from within Run, it also stops the execution of the function, similar to *testing.T.
+func (t *TestingT) FailNow() {
+	t.mux.Lock()
+	t.failNowCalls++
+	if len(t.failures) > 0 {
+		t.failures[len(t.failures)-1].Fatal = true
+	}
+	goexit := t.goexit
+	t.mux.Unlock()
+	if goexit {
+		runtime.Goexit()
+	}
+}
+
+// Name returns the name given to the recorder.
+func (t *TestingT) Name() string {
+	return t.name
+}
+
+// RecordFailure is called by testarossa to record a failed assertion.
+func (t *TestingT) RecordFailure(file string, line int, message string, labels []testarossa.Label) {
+	t.mux.Lock()
+	t.failures = append(t.failures, Failure{
+		Message: message,
+		File:    file,
+		Line:    line,
+		Labels:  labels,
+	})
+	t.mux.Unlock()
+}
+
+// Failed indicates if Fail or FailNow were called.
+func (t *TestingT) Failed() bool {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	return t.failCalls > 0 || t.failNowCalls > 0
+}
+
+// Failures returns the failed assertions captured so far.
+func (t *TestingT) Failures() []Failure {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	failures := make([]Failure, len(t.failures))
+	copy(failures, t.failures)
+	return failures
+}
+
+// FailCalls returns the number of times Fail was called.
+func (t *TestingT) FailCalls() int {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	return t.failCalls
+}
+
+// FailNowCalls returns the number of times FailNow was called.
+func (t *TestingT) FailNowCalls() int {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	return t.failNowCalls
+}
+
+// Reset clears all captured failures and calls.
+func (t *TestingT) Reset() {
+	t.mux.Lock()
+	t.failures = nil
+	t.failCalls = 0
+	t.failNowCalls = 0
+	t.mux.Unlock()
+}
+
+// Run runs the function on a separate goroutine and waits for it to complete.
+// A call to FailNow stops the execution of the function, similar to *testing.T.
+func (t *TestingT) Run(fn func(t testarossa.TestingT)) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		t.mux.Lock()
+		t.goexit = true
+		t.mux.Unlock()
+		defer func() {
+			t.mux.Lock()
+			t.goexit = false
+			t.mux.Unlock()
+		}()
+		fn(t)
+	}()
+	<-done
+}
+
+// ExpectFailure fails the test if the function does not fail,
+// or if none of its failure messages match the regular expression.
+// An empty regular expression matches any failure, including one without a message.
+func ExpectFailure(t testarossa.TestingT, fn func(t testarossa.TestingT), messageRegexp string, args ...any) bool {
+	re, err := regexp.Compile(messageRegexp)
+	if err != nil {
+		msgArgs := []any{"Invalid regular expression '%s': %s", messageRegexp, err.Error()}
+		testarossa.FailIf(
+			t,
+			true,
+			append(msgArgs, args...)...,
+		)
+		return false
+	}
+	recorder := New(t.Name())
+	recorder.Run(fn)
+	if !recorder.Failed() {
+		msgArgs := []any{"Expected failure"}
+		testarossa.FailIf(
+			t,
+			true,
+			append(msgArgs, args...)...,
+		)
+		return false
+	}
+	if messageRegexp == "" {
+		return true
+	}
+	var messages []string
+	for _, f := range recorder.Failures() {
+		if re.MatchString(f.Message) {
+			return true
+		}
+		messages = append(messages, f.String())
+	}
+	msgArgs := []any{"Expected a failure message to match regular expression '%s'", messageRegexp}
+	if len(messages) > 0 {
+		msgArgs = append(msgArgs, "Actual failures", "%s", strings.Join(messages, "\n"))
+	}
+	testarossa.FailIf(
+		t,
+		true,
+		append(msgArgs, args...)...,
+	)
+	return false
+}
+
+// ExpectPass fails the test if the function fails.
+func ExpectPass(t testarossa.TestingT, fn func(t testarossa.TestingT), args ...any) bool {
+	recorder := New(t.Name())
+	recorder.Run(fn)
+	if !recorder.Failed() {
+		return true
+	}
+	var messages []string
+	for _, f := range recorder.Failures() {
+		messages = append(messages, f.String())
+	}
+	msgArgs := []any{"Expected to pass", "%s", strings.Join(messages, "\n")}
+	testarossa.FailIf(
+		t,
+		true,
+		append(msgArgs, args...)...,
+	)
+	return false
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2024-2025 Microbus LLC and various contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testarossatest
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/microbus-io/testarossa"
+)
+
+func positive(t testarossa.TestingT, n int, args ...any) bool {
+	msgArgs := []any{"Expected positive, actual %d", n}
+	return !testarossa.FailIf(t, n <= 0, append(msgArgs, args...)...)
+}
+
+func positiveBuilt(t testarossa.TestingT, n int, args ...any) bool {
+	return !testarossa.Assertion(t).
+		Actual(n).
+		Message("Expected positive").
+		Message(args...).
+		FailIf(n <= 0)
+}
+
+func TestTestingT_Record(t *testing.T) {
+	tt := testarossa.For(t)
+
+	rec := New("Recorder")
+	tt.Equal("Recorder", rec.Name())
+	tt.False(rec.Failed())
+
+	testarossa.Equal(rec, 1, 2, "Values of %s", "x")
+	tt.True(rec.Failed())
+	tt.Equal(1, rec.FailCalls())
+	tt.Equal(0, rec.FailNowCalls())
+	failures := rec.Failures()
+	if tt.Len(failures, 1) {
+		tt.Equal("Expected '1', actual '2'\nValues of x", failures[0].Message)
+		tt.True(strings.HasSuffix(failures[0].File, "testarossatest_test.go"))
+		tt.NotZero(failures[0].Line)
+		tt.False(failures[0].Fatal)
+	}
+
+	testarossa.FatalIf(rec, true, "Fatal")
+	tt.Equal(2, rec.FailCalls())
+	tt.Equal(1, rec.FailNowCalls())
+	failures = rec.Failures()
+	if tt.Len(failures, 2) {
+		tt.Equal("Fatal", failures[1].Message)
+		tt.True(failures[1].Fatal)
+	}
+
+	rec.Reset()
+	tt.False(rec.Failed())
+	tt.Len(rec.Failures(), 0)
+}
+
+func TestTestingT_Labels(t *testing.T) {
+	tt := testarossa.For(t)
+
+	rec := New("Recorder")
+	testarossa.For(rec).With("tenant", "acme").With("shard", 3).Equal(1, 2)
+	failures := rec.Failures()
+	if tt.Len(failures, 1) {
+		tt.Equal([]testarossa.Label{{Key: "tenant", Value: "acme"}, {Key: "shard", Value: 3}}, failures[0].Labels)
+		tt.Contains(failures[0].String(), "\ntenant=acme shard=3\nExpected '1', actual '2'")
+	}
+}
+
+func TestTestingT_Run(t *testing.T) {
+	tt := testarossa.For(t)
+
+	rec := New("Recorder")
+	reached := false
+	rec.Run(func(t testarossa.TestingT) {
+		testarossa.FatalIf(t, true, "Stop")
+		reached = true
+	})
+	tt.False(reached)
+	tt.True(rec.Failed())
+	tt.Equal(1, rec.FailNowCalls())
+
+	// FailNow does not stop execution outside of Run
+	testarossa.FatalIf(rec, true, "Continue")
+	tt.Equal(2, rec.FailNowCalls())
+}
+
+func TestTestingT_ExpectFailure(t *testing.T) {
+	tt := testarossa.For(t)
+
+	tt.True(ExpectFailure(t, func(t testarossa.TestingT) {
+		positive(t, -1)
+	}, "^Expected positive, actual -1$"))
+	tt.True(ExpectFailure(t, func(t testarossa.TestingT) {
+		positive(t, 0, "Zero")
+	}, "Zero"))
+	tt.True(ExpectPass(t, func(t testarossa.TestingT) {
+		positive(t, 1)
+	}))
+	tt.True(ExpectFailure(t, func(t testarossa.TestingT) {
+		t.FailNow()
+	}, ""))
+
+	rec := New("Recorder")
+	_, _, line, _ := runtime.Caller(0)
+	tt.False(ExpectFailure(rec, func(t testarossa.TestingT) {
+		positive(t, 1)
+	}, ""))
+	tt.False(ExpectFailure(rec, func(t testarossa.TestingT) {
+		positive(t, -1)
+	}, "negative"))
+	tt.False(ExpectFailure(rec, func(t testarossa.TestingT) {}, "[invalid"))
+	tt.False(ExpectPass(rec, func(t testarossa.TestingT) {
+		positive(t, -1)
+	}))
+	failures := rec.Failures()
+	if tt.Len(failures, 4) {
+		tt.Equal("Expected failure", failures[0].Message)
+		tt.Equal(line+1, failures[0].Line)
+		tt.True(strings.HasSuffix(failures[0].File, "testarossatest_test.go"))
+		tt.Contains(failures[1].Message, "Expected positive, actual -1")
+		tt.Contains(failures[2].Message, "Invalid regular expression")
+		tt.Contains(failures[3].Message, "Expected to pass")
+	}
+}
+
+func TestTestingT_AssertionHelperFrame(t *testing.T) {
+	tt := testarossa.For(t)
+
+	rec := New("Recorder")
+	_, file, line, _ := runtime.Caller(0)
+	positiveBuilt(rec, -1)
+	failures := rec.Failures()
+	if tt.Len(failures, 1) {
+		tt.Equal(file, failures[0].File)
+		tt.Equal(line+1, failures[0].Line)
+		tt.Equal("Actual '-1'\nExpected positive", failures[0].Message)
+	}
+
+	// Frames of custom assertions built directly on FailIf are not omitted
+	rec.Reset()
+	positive(rec, -1)
+	failures = rec.Failures()
+	if tt.Len(failures, 1) {
+		tt.NotEqual(line+1, failures[0].Line)
+	}
+}
@@ -0,0 +1,50 @@
+//go:build linux
+
+/*
+Copyright 2024-2025 Microbus LLC and various contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testarossa
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// umask returns the umask of the process, as listed in /proc/self/status.
+// Unlike setting the umask in order to read it, this does not affect files created concurrently.
+// It returns false on kernels that do not list the umask.
+func umask() (mask int, ok bool) {
+	f, err := os.Open("/proc/self/status")
+	if err != nil {
+		return 0, false
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		val, found := strings.CutPrefix(scanner.Text(), "Umask:")
+		if !found {
+			continue
+		}
+		m, err := strconv.ParseInt(strings.TrimSpace(val), 8, 32)
+		if err != nil {
+			return 0, false
+		}
+		return int(m), true
+	}
+	return 0, false
+}
@@ -0,0 +1,32 @@
+//go:build unix && !linux
+
+/*
+Copyright 2024-2025 Microbus LLC and various contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testarossa
+
+import (
+	"syscall"
+)
+
+// umask returns the umask of the process.
+// The umask can only be read by setting it, so it is momentarily set to 0 and then restored.
+// Files created by other goroutines in that window are created with a umask of 0.
+func umask() (mask int, ok bool) {
+	mask = syscall.Umask(0)
+	syscall.Umask(mask)
+	return mask, true
+}